@@ -0,0 +1,151 @@
+// Package analytics calcula indicadores sobre séries temporais de casos e
+// mortes: médias móveis, variação percentual, razão de crescimento, tempo de
+// duplicação e picos. As funções são puras e não dependem do banco de dados.
+package analytics
+
+import (
+	"math"
+	"time"
+)
+
+// Point é um valor da série. Em séries de casos novos, o valor é a
+// quantidade desde o ponto anterior (e.g. a semana do relatório da OMS).
+type Point struct {
+	Date  time.Time
+	Value float64
+}
+
+// Series é uma série temporal em ordem crescente de data
+type Series []Point
+
+// Soma dos valores com data no intervalo (end - days, end]. ok é falso quando
+// a janela começa antes do primeiro ponto da série, ou seja, está incompleta.
+func (s Series) windowSum(end time.Time, days int) (sum float64, ok bool) {
+	if len(s) == 0 {
+		return 0, false
+	}
+	start := end.AddDate(0, 0, -days)
+	if start.Before(s[0].Date) {
+		return 0, false
+	}
+	for _, p := range s {
+		if p.Date.After(start) && !p.Date.After(end) {
+			sum += p.Value
+		}
+	}
+	return sum, true
+}
+
+// RollingMean retorna, para cada ponto, a média diária dos últimos days dias
+// (soma da janela dividida por days). Assim uma série semanal e uma diária
+// produzem médias comparáveis. O valor é nil enquanto a janela estiver
+// incompleta.
+func RollingMean(s Series, days int) []*float64 {
+	means := make([]*float64, len(s))
+	for i, p := range s {
+		if sum, ok := s.windowSum(p.Date, days); ok {
+			means[i] = value(sum / float64(days))
+		}
+	}
+	return means
+}
+
+// Soma da janela de days dias terminada no ponto e da janela anterior
+func (s Series) windows(end time.Time, days int) (current, previous float64, ok bool) {
+	current, ok = s.windowSum(end, days)
+	if !ok {
+		return 0, 0, false
+	}
+	previous, ok = s.windowSum(end.AddDate(0, 0, -days), days)
+	return current, previous, ok
+}
+
+// PercentChange retorna a variação percentual da soma dos últimos days dias
+// em relação aos days dias anteriores (e.g. semana contra semana com days =
+// 7). O valor é nil com janelas incompletas ou sem valores na janela anterior.
+func PercentChange(s Series, days int) []*float64 {
+	changes := make([]*float64, len(s))
+	for i, p := range s {
+		current, previous, ok := s.windows(p.Date, days)
+		if ok && previous > 0 {
+			changes[i] = value((current - previous) * 100 / previous)
+		}
+	}
+	return changes
+}
+
+// GrowthRatio retorna a razão entre a soma dos últimos days dias e a dos days
+// dias anteriores, uma aproximação do número de reprodução: acima de 1 a
+// série cresce, abaixo de 1 ela decresce.
+func GrowthRatio(s Series, days int) []*float64 {
+	ratios := make([]*float64, len(s))
+	for i, p := range s {
+		current, previous, ok := s.windows(p.Date, days)
+		if ok && previous > 0 {
+			ratios[i] = value(current / previous)
+		}
+	}
+	return ratios
+}
+
+// DoublingTime retorna, em dias, o tempo para a série dobrar mantendo a razão
+// de crescimento da janela de days dias. O valor é nil quando a série não
+// está crescendo.
+func DoublingTime(s Series, days int) []*float64 {
+	times := make([]*float64, len(s))
+	for i, ratio := range GrowthRatio(s, days) {
+		if ratio != nil && *ratio > 1 {
+			times[i] = value(float64(days) * math.Ln2 / math.Log(*ratio))
+		}
+	}
+	return times
+}
+
+// Peak retorna o ponto de maior valor da série (o primeiro, em caso de
+// empate). ok é falso para uma série vazia.
+func Peak(s Series) (peak Point, ok bool) {
+	for i, p := range s {
+		if i == 0 || p.Value > peak.Value {
+			peak = p
+		}
+	}
+	return peak, len(s) > 0
+}
+
+// Peaks retorna os picos locais da série: pontos com valor positivo que são o
+// maior valor entre os pontos a até minDistance dias antes ou depois. Em um
+// platô, apenas o primeiro ponto é considerado pico.
+func Peaks(s Series, minDistance int) []Point {
+	var peaks []Point
+	for _, i := range peakIndexes(s, minDistance) {
+		peaks = append(peaks, s[i])
+	}
+	return peaks
+}
+
+func peakIndexes(s Series, minDistance int) []int {
+	var peaks []int
+	for i, p := range s {
+		if p.Value <= 0 {
+			continue
+		}
+		isPeak := true
+		for j, q := range s {
+			if j == i || math.Abs(q.Date.Sub(p.Date).Hours()/24) > float64(minDistance) {
+				continue
+			}
+			if q.Value > p.Value || (q.Value == p.Value && j < i) {
+				isPeak = false
+				break
+			}
+		}
+		if isPeak {
+			peaks = append(peaks, i)
+		}
+	}
+	return peaks
+}
+
+func value(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,75 @@
+package analytics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Builds a weekly series starting on 2021-01-03
+func weekly(values ...float64) Series {
+	start := time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)
+	s := make(Series, len(values))
+	for i, v := range values {
+		s[i] = Point{Date: start.AddDate(0, 0, 7*i), Value: v}
+	}
+	return s
+}
+
+func values(ptrs []*float64) []interface{} {
+	out := make([]interface{}, len(ptrs))
+	for i, p := range ptrs {
+		if p != nil {
+			out[i] = *p
+		}
+	}
+	return out
+}
+
+// Tests that weekly reports become daily means over 7 and 14 day windows
+func TestRollingMean(t *testing.T) {
+	s := weekly(70, 140, 210)
+
+	assert.Equal(t, []interface{}{nil, 20.0, 30.0}, values(RollingMean(s, 7)))
+	assert.Equal(t, []interface{}{nil, nil, 25.0}, values(RollingMean(s, 14)))
+}
+
+// Tests the week over week change and the growth ratio
+func TestPercentChangeAndGrowthRatio(t *testing.T) {
+	s := weekly(100, 100, 200, 100, 0, 50)
+
+	assert.Equal(t, []interface{}{nil, nil, 100.0, -50.0, -100.0, nil}, values(PercentChange(s, 7)))
+	assert.Equal(t, []interface{}{nil, nil, 2.0, 0.5, 0.0, nil}, values(GrowthRatio(s, 7)))
+}
+
+// Tests that the doubling time only exists while the series grows
+func TestDoublingTime(t *testing.T) {
+	s := weekly(100, 100, 200, 400, 300)
+
+	times := DoublingTime(s, 7)
+	assert.Nil(t, times[1])
+	assert.InDelta(t, 7.0, *times[2], 1e-9)
+	assert.InDelta(t, 7.0, *times[3], 1e-9)
+	assert.Nil(t, times[4])
+
+	s = weekly(100, 100, 150)
+	assert.InDelta(t, 7*math.Ln2/math.Log(1.5), *DoublingTime(s, 7)[2], 1e-9)
+}
+
+// Tests the global and local peaks of a series with two waves
+func TestPeaks(t *testing.T) {
+	s := weekly(10, 50, 30, 5, 20, 80, 80, 10)
+
+	peak, ok := Peak(s)
+	assert.True(t, ok)
+	assert.Equal(t, s[5], peak)
+
+	assert.Equal(t, []Point{s[1], s[5]}, Peaks(s, 14))
+	// A wider distance keeps only the highest peak
+	assert.Equal(t, []Point{s[5]}, Peaks(s, 28))
+
+	_, ok = Peak(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,105 @@
+package analytics
+
+import "time"
+
+// Wave é uma onda da série: do vale que a antecede ao vale que a encerra,
+// passando pelo pico
+type Wave struct {
+	Start     time.Time
+	Peak      time.Time
+	End       time.Time
+	PeakValue float64
+	Total     float64 // Soma dos valores após o início até o fim, inclusive
+}
+
+// WaveOptions controla a detecção de ondas
+type WaveOptions struct {
+	// Distância mínima, em dias, entre os picos de duas ondas
+	MinDistance int
+	// Fração do maior valor da série que um pico precisa atingir para iniciar
+	// uma onda, descartando oscilações pequenas
+	MinPeakShare float64
+	// Dois picos só são ondas diferentes se o vale entre eles ficar abaixo
+	// dessa fração do menor dos dois; caso contrário, formam uma única onda
+	MaxTroughShare float64
+}
+
+// DefaultWaveOptions retorna as opções padrão para séries semanais da OMS
+func DefaultWaveOptions() WaveOptions {
+	return WaveOptions{MinDistance: 28, MinPeakShare: 0.1, MaxTroughShare: 0.5}
+}
+
+// Waves identifica as ondas da série a partir dos seus picos locais. Cada
+// onda começa no menor valor antes do pico (o vale entre ela e a onda
+// anterior) e termina no vale seguinte; a última termina no menor valor após
+// o pico. Se a série já começa dentro da primeira onda, o primeiro valor
+// também entra no total dela.
+func Waves(s Series, opts WaveOptions) []Wave {
+	peak, ok := Peak(s)
+	if !ok || peak.Value <= 0 {
+		return nil
+	}
+
+	// Picos relevantes, unindo os que não são separados por um vale profundo
+	var peaks []int
+	for _, i := range peakIndexes(s, opts.MinDistance) {
+		if s[i].Value < opts.MinPeakShare*peak.Value {
+			continue
+		}
+		if len(peaks) > 0 {
+			last := peaks[len(peaks)-1]
+			trough := s[minIndex(s, last, i, false)].Value
+			if trough > opts.MaxTroughShare*minValue(s[last].Value, s[i].Value) {
+				if s[i].Value > s[last].Value {
+					peaks[len(peaks)-1] = i
+				}
+				continue
+			}
+		}
+		peaks = append(peaks, i)
+	}
+
+	// Limites das ondas: o início da primeira, os vales entre os picos e o fim
+	// da última
+	bounds := []int{minIndex(s, 0, peaks[0], true)}
+	for i := 1; i < len(peaks); i++ {
+		bounds = append(bounds, minIndex(s, peaks[i-1], peaks[i], false))
+	}
+	bounds = append(bounds, minIndex(s, peaks[len(peaks)-1], len(s)-1, false))
+
+	waves := make([]Wave, len(peaks))
+	for i, p := range peaks {
+		start, end := bounds[i], bounds[i+1]
+		wave := Wave{Start: s[start].Date, Peak: s[p].Date, End: s[end].Date, PeakValue: s[p].Value}
+		// O vale inicial pertence à onda anterior, exceto quando a série começa
+		// no meio da onda (e.g. cortada por from) e o primeiro valor não é um vale
+		first := start + 1
+		if start == 0 && s[0].Value > opts.MaxTroughShare*s[p].Value {
+			first = 0
+		}
+		for j := first; j <= end; j++ {
+			wave.Total += s[j].Value
+		}
+		waves[i] = wave
+	}
+	return waves
+}
+
+// Índice do menor valor entre from e to, inclusive. Em caso de empate
+// retorna o último índice se latest for verdadeiro, ou o primeiro.
+func minIndex(s Series, from, to int, latest bool) int {
+	min := from
+	for i := from + 1; i <= to; i++ {
+		if s[i].Value < s[min].Value || (latest && s[i].Value == s[min].Value) {
+			min = i
+		}
+	}
+	return min
+}
+
+func minValue(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
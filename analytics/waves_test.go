@@ -0,0 +1,60 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests two waves separated by a deep trough
+func TestWaves(t *testing.T) {
+	s := weekly(0, 10, 50, 30, 5, 20, 80, 80, 10, 0)
+
+	waves := Waves(s, WaveOptions{MinDistance: 14, MinPeakShare: 0.1, MaxTroughShare: 0.5})
+	assert.Equal(t, []Wave{
+		{Start: s[0].Date, Peak: s[2].Date, End: s[4].Date, PeakValue: 50, Total: 95},
+		{Start: s[4].Date, Peak: s[6].Date, End: s[9].Date, PeakValue: 80, Total: 190},
+	}, waves)
+}
+
+// Tests that peaks without a deep trough between them form a single wave
+func TestWaves_MergesShallowTroughs(t *testing.T) {
+	s := weekly(0, 50, 40, 60, 0)
+
+	waves := Waves(s, WaveOptions{MinDistance: 7, MinPeakShare: 0.1, MaxTroughShare: 0.5})
+	assert.Equal(t, []Wave{
+		{Start: s[0].Date, Peak: s[3].Date, End: s[4].Date, PeakValue: 60, Total: 150},
+	}, waves)
+}
+
+// Tests that small oscillations and empty series produce no extra waves
+func TestWaves_IgnoresSmallPeaks(t *testing.T) {
+	s := weekly(0, 2, 0, 0, 100, 0)
+
+	waves := Waves(s, WaveOptions{MinDistance: 7, MinPeakShare: 0.1, MaxTroughShare: 0.5})
+	assert.Len(t, waves, 1)
+	assert.Equal(t, s[4].Date, waves[0].Peak)
+	assert.Equal(t, s[3].Date, waves[0].Start)
+
+	assert.Nil(t, Waves(weekly(0, 0), DefaultWaveOptions()))
+}
+
+// Tests that a series starting at or near the peak keeps its first value in
+// the total, as happens when the series is trimmed by a start date
+func TestWaves_StartsInsideWave(t *testing.T) {
+	opts := WaveOptions{MinDistance: 7, MinPeakShare: 0.1, MaxTroughShare: 0.5}
+
+	waves := Waves(weekly(100, 50, 0), opts)
+	assert.Len(t, waves, 1)
+	assert.Equal(t, 100.0, waves[0].PeakValue)
+	assert.Equal(t, 150.0, waves[0].Total)
+
+	waves = Waves(weekly(80, 100, 50, 0), opts)
+	assert.Len(t, waves, 1)
+	assert.Equal(t, 230.0, waves[0].Total)
+
+	// A low first value is a trough and stays out of the total
+	waves = Waves(weekly(10, 100, 50, 0), opts)
+	assert.Len(t, waves, 1)
+	assert.Equal(t, 150.0, waves[0].Total)
+}
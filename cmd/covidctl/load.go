@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"desafiogolang-neo4j/loader"
+)
+
+// Conjuntos de dados aceitos pelo comando load, na ordem usada por "load all"
+var datasets = []struct {
+	name string
+	file string // Arquivo padrão dentro do diretório de dados
+	load func(l *loader.Loader, ctx context.Context, filePath string) error
+}{
+	{"metadata", "vaccination-metadata.csv", (*loader.Loader).LoadVaccinationMetadata},
+	{"vaccination", "vaccination-data.csv", (*loader.Loader).LoadVaccinationData},
+	{"global", "WHO-COVID-19-global-data.csv", (*loader.Loader).LoadGlobalData},
+	{"population", "population.csv", (*loader.Loader).LoadPopulation},
+}
+
+func runLoad(ctx context.Context, args []string) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return errors.New("missing dataset: expected global, vaccination, metadata, population or all")
+	}
+	target := args[0]
+
+	fs := flag.NewFlagSet("load "+target, flag.ExitOnError)
+	conn := connectionFlags(fs)
+	file := fs.String("file", "", "CSV file to load (required unless the dataset is all)")
+	dataDir := fs.String("data-dir", "data", "Directory with the WHO CSV files, used by load all")
+	opts := loader.DefaultOptions()
+	fs.IntVar(&opts.BatchSize, "batch-size", envInt("LOAD_BATCH_SIZE", opts.BatchSize), "Rows written per transaction")
+	mode := fs.String("mode", envString("LOAD_MODE", "strict"), "strict aborts on the first invalid row, lenient rejects it and goes on")
+	fs.IntVar(&opts.MaxErrors, "max-errors", envInt("LOAD_MAX_ERRORS", opts.MaxErrors), "Rejected rows tolerated in lenient mode before aborting (0 = no limit)")
+	fs.StringVar(&opts.RejectsFile, "rejects-file", envString("LOAD_REJECTS_FILE", opts.RejectsFile), "Report of the rows rejected in lenient mode")
+	fs.BoolVar(&opts.Full, "full", envString("LOAD_FULL", "false") == "true", "Ignore the watermark and reload every row")
+	fs.IntVar(&opts.PopulationYear, "population-year", envInt("LOAD_POPULATION_YEAR", opts.PopulationYear), "Year of the World Population Prospects estimates loaded by load population")
+	fs.Parse(args[1:])
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	// --file só vale para um conjunto de dados e --data-dir só para load all
+	if target == "all" && *file != "" {
+		return errors.New("--file cannot be used with 'load all', use --data-dir")
+	}
+	dataDirSet := false
+	fs.Visit(func(f *flag.Flag) { dataDirSet = dataDirSet || f.Name == "data-dir" })
+	if target != "all" && dataDirSet {
+		return errors.New("--data-dir can only be used with 'load all', use --file")
+	}
+
+	switch *mode {
+	case "strict":
+	case "lenient":
+		opts.Lenient = true
+	default:
+		return fmt.Errorf("invalid mode %q, expected strict or lenient", *mode)
+	}
+
+	type job struct {
+		filePath string
+		load     func(l *loader.Loader, ctx context.Context, filePath string) error
+	}
+	var jobs []job
+	for _, ds := range datasets {
+		switch {
+		case target == "all":
+			jobs = append(jobs, job{filepath.Join(*dataDir, ds.file), ds.load})
+		case target == ds.name:
+			if *file == "" {
+				return errors.New("missing --file")
+			}
+			jobs = append(jobs, job{*file, ds.load})
+		}
+	}
+	if len(jobs) == 0 {
+		return fmt.Errorf("unknown dataset %q: expected global, vaccination, metadata, population or all", target)
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := loader.ApplySchema(ctx, session); err != nil {
+		return err
+	}
+	fmt.Println("Constraints and indexes created successfully!")
+
+	l, err := loader.New(session, opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Starting to load data...")
+	for _, j := range jobs {
+		if err = j.load(l, ctx, j.filePath); err != nil {
+			break
+		}
+	}
+	if closeErr := l.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("All data loaded successfully!")
+	return nil
+}
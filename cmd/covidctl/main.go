@@ -0,0 +1,129 @@
+// Command covidctl carrega e mantém os dados de Covid-19 da OMS no Neo4j.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const usage = `Usage: covidctl <command> [flags]
+
+Commands:
+  load <global|vaccination|metadata|population> --file PATH   Load one CSV file
+  load all [--data-dir DIR]                                   Load every file from the data directory
+  schema apply                                                Create constraints and indexes
+  wipe --yes                                                  Delete every node and relationship
+  migrate --yes                                               Delete the countries loaded with two-letter codes
+  stats                                                       Count nodes and relationships
+  verify                                                      Run integrity checks on the graph
+
+Run 'covidctl <command> -h' to list the flags of a command.
+Connection flags default to NEO4J_URI, NEO4J_USER and NEO4J_PASSWORD.
+`
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	command, args := os.Args[1], os.Args[2:]
+
+	var err error
+	switch command {
+	case "load":
+		err = runLoad(ctx, args)
+	case "schema":
+		err = runSchema(ctx, args)
+	case "wipe":
+		err = runWipe(ctx, args)
+	case "migrate":
+		err = runMigrate(ctx, args)
+	case "stats":
+		err = runStats(ctx, args)
+	case "verify":
+		err = runVerify(ctx, args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatalf("covidctl %s: %v", command, err)
+	}
+}
+
+// Parâmetros de conexão com o Neo4j, comuns a todos os comandos
+type connection struct {
+	uri      string
+	user     string
+	password string
+}
+
+func connectionFlags(fs *flag.FlagSet) *connection {
+	c := &connection{}
+	fs.StringVar(&c.uri, "uri", os.Getenv("NEO4J_URI"), "Neo4j URI")
+	fs.StringVar(&c.user, "user", os.Getenv("NEO4J_USER"), "Neo4j user")
+	fs.StringVar(&c.password, "password", os.Getenv("NEO4J_PASSWORD"), "Neo4j password")
+	return c
+}
+
+// Abre o driver e uma sessão de escrita; done libera os dois
+func (c *connection) open(ctx context.Context) (session neo4j.SessionWithContext, done func(), err error) {
+	fmt.Println("Connecting to Neo4j...")
+	driver, err := neo4j.NewDriverWithContext(c.uri, neo4j.BasicAuth(c.user, c.password, ""))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create driver: %w", err)
+	}
+	if err := driver.VerifyConnectivity(ctx); err != nil {
+		driver.Close(ctx)
+		return nil, nil, fmt.Errorf("could not connect: %w", err)
+	}
+	fmt.Println("Connection established!")
+
+	session = driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	return session, func() {
+		session.Close(ctx)
+		driver.Close(ctx)
+	}, nil
+}
+
+// Lê um inteiro de uma variável de ambiente, usado como valor padrão de uma flag
+func envInt(name string, def int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return def
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", name, err)
+	}
+	return n
+}
+
+func envString(name, def string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return def
+}
+
+// Garante que o comando não recebeu argumentos além das flags
+func noArgs(fs *flag.FlagSet) error {
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+
+	"desafiogolang-neo4j/loader"
+)
+
+func runSchema(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "apply" {
+		return errors.New("expected 'schema apply'")
+	}
+
+	fs := flag.NewFlagSet("schema apply", flag.ExitOnError)
+	conn := connectionFlags(fs)
+	fs.Parse(args[1:])
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := loader.ApplySchema(ctx, session); err != nil {
+		return err
+	}
+	fmt.Println("Constraints and indexes created successfully!")
+	return nil
+}
+
+func runWipe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("wipe", flag.ExitOnError)
+	conn := connectionFlags(fs)
+	yes := fs.Bool("yes", false, "Confirm that every node and relationship must be deleted")
+	fs.Parse(args)
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if !*yes {
+		return errors.New("refusing to delete the whole graph without --yes")
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	if err := loader.Wipe(ctx, session); err != nil {
+		return err
+	}
+	fmt.Println("All nodes and relationships deleted!")
+	return nil
+}
+
+func runMigrate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	conn := connectionFlags(fs)
+	yes := fs.Bool("yes", false, "Confirm that the legacy countries and their statistics must be deleted")
+	fs.Parse(args)
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+	if !*yes {
+		return errors.New("refusing to delete the legacy countries without --yes")
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	count, err := loader.MigrateLegacyCountries(ctx, session)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d legacy countries deleted! Run 'covidctl load all' to reload their data.\n", count)
+	return nil
+}
+
+func runStats(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	conn := connectionFlags(fs)
+	fs.Parse(args)
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	nodes, relationships, err := loader.Stats(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Nodes:")
+	for _, count := range nodes {
+		fmt.Printf("  %-20s %d\n", count.Name, count.Count)
+	}
+	fmt.Println("Relationships:")
+	for _, count := range relationships {
+		fmt.Printf("  %-20s %d\n", count.Name, count.Count)
+	}
+	return nil
+}
+
+func runVerify(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	conn := connectionFlags(fs)
+	fs.Parse(args)
+	if err := noArgs(fs); err != nil {
+		return err
+	}
+
+	session, done, err := conn.open(ctx)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	checks, err := loader.Verify(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, check := range checks {
+		status := "OK"
+		if check.Problems > 0 {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("  %-4s %-45s %d\n", status, check.Name, check.Problems)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	fmt.Println("All checks passed!")
+	return nil
+}
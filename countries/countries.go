@@ -0,0 +1,57 @@
+// Package countries reconcilia os códigos de país usados pelos arquivos da OMS
+// (ISO 3166-1 alfa-2, alfa-3 e numérico) a partir de uma tabela embutida e
+// compara nomes de países nas buscas.
+package countries
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+)
+
+//go:embed iso3166.csv
+var iso3166 string
+
+// Country é a identidade de um país segundo a ISO 3166-1
+type Country struct {
+	Name    string
+	ISO2    string
+	ISO3    string
+	Numeric string
+}
+
+var byCode = map[string]Country{}
+
+func init() {
+	reader := csv.NewReader(strings.NewReader(iso3166))
+	reader.Comma = ';'
+	records, err := reader.ReadAll()
+	if err != nil {
+		panic("countries: invalid iso3166.csv: " + err.Error())
+	}
+	for _, record := range records[1:] { // Ignorar cabeçalho
+		country := Country{ISO2: record[0], ISO3: record[1], Numeric: record[2], Name: record[3]}
+		byCode[country.ISO2] = country
+		byCode[country.ISO3] = country
+		if country.Numeric != "" {
+			byCode[country.Numeric] = country
+		}
+	}
+}
+
+// Lookup encontra o país por qualquer um dos seus códigos (alfa-2, alfa-3 ou
+// numérico), sem diferenciar maiúsculas e minúsculas.
+func Lookup(code string) (Country, bool) {
+	country, ok := byCode[strings.ToUpper(strings.TrimSpace(code))]
+	return country, ok
+}
+
+// Canonical retorna o código alfa-3 usado como identidade do nó Country.
+// Códigos fora da tabela (e.g. XXA, usados pela OMS para embarcações) são
+// devolvidos sem alteração, apenas normalizados.
+func Canonical(code string) string {
+	if country, ok := Lookup(code); ok {
+		return country.ISO3
+	}
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,27 @@
+package countries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that every code form resolves to the same country
+func TestLookup(t *testing.T) {
+	for _, code := range []string{"US", "USA", "840", "us", " usa "} {
+		country, ok := Lookup(code)
+		assert.True(t, ok, code)
+		assert.Equal(t, Country{Name: "United States of America", ISO2: "US", ISO3: "USA", Numeric: "840"}, country)
+	}
+
+	_, ok := Lookup("XXL")
+	assert.False(t, ok)
+}
+
+// Tests that the WHO specific Kosovo codes are reconciled
+func TestCanonical(t *testing.T) {
+	assert.Equal(t, "XKX", Canonical("XK"))
+	assert.Equal(t, "XKX", Canonical("XKX"))
+	assert.Equal(t, "BRA", Canonical("br"))
+	assert.Equal(t, "XXL", Canonical("xxl"))
+}
@@ -0,0 +1,114 @@
+package countries
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Letras acentuadas comuns nos nomes de países e a letra sem acento
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n", "ý", "y",
+)
+
+// Fold normaliza um nome para comparação: minúsculas, sem acentos e com a
+// pontuação trocada por espaços simples (e.g. "Côte d’Ivoire" vira
+// "cote d ivoire")
+func Fold(name string) string {
+	name = accents.Replace(strings.ToLower(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// MatchName indica se name corresponde à busca query e com qual relevância
+// (menor é melhor): nome igual, prefixo do nome, prefixo de uma palavra,
+// trecho do nome e, por fim, palavras parecidas, tolerando erros de digitação
+// proporcionais ao tamanho da busca.
+func MatchName(query, name string) (rank int, ok bool) {
+	query, name = Fold(query), Fold(name)
+	switch {
+	case query == "":
+		return 0, false
+	case name == query:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	case wordPrefix(query, name):
+		return 2, true
+	case strings.Contains(name, query):
+		return 3, true
+	}
+
+	// Compara a busca com o trecho do nome de mesmo tamanho a partir de cada
+	// palavra, para que "brazl" encontre "brazil" e "untied" encontre "united"
+	tolerance := len([]rune(query)) / 4
+	if tolerance == 0 {
+		return 0, false
+	}
+	best := tolerance + 1
+	words := strings.Fields(name)
+	for i := range words {
+		candidate := []rune(strings.Join(words[i:], " "))
+		for _, size := range []int{len([]rune(query)) - 1, len([]rune(query)), len([]rune(query)) + 1} {
+			if size <= 0 || size > len(candidate) {
+				continue
+			}
+			if d := distance(query, string(candidate[:size])); d < best {
+				best = d
+			}
+		}
+	}
+	if best > tolerance {
+		return 0, false
+	}
+	return 3 + best, true
+}
+
+// Indica se query é prefixo do nome a partir de alguma palavra que não a
+// primeira (e.g. "states of" em "united states of america")
+func wordPrefix(query, name string) bool {
+	words := strings.Fields(name)
+	for i := 1; i < len(words); i++ {
+		if strings.HasPrefix(strings.Join(words[i:], " "), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Distância de Levenshtein entre a e b
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,53 @@
+package countries
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that accents and punctuation are ignored when comparing names
+func TestFold(t *testing.T) {
+	assert.Equal(t, "cote d ivoire", Fold("Côte d’Ivoire"))
+	assert.Equal(t, "turkiye", Fold("  TÜRKIYE "))
+	assert.Equal(t, "bolivia plurinational state of", Fold("Bolivia (Plurinational State of)"))
+}
+
+// Tests that a word prefix must start at a word boundary after the first word
+func TestWordPrefix(t *testing.T) {
+	assert.True(t, wordPrefix("sta", "united states of america"))
+	assert.True(t, wordPrefix("states of am", "united states of america"))
+	assert.True(t, wordPrefix("ivo", "cote d ivoire"))
+	assert.False(t, wordPrefix("uni", "united states of america"))
+	assert.False(t, wordPrefix("tates", "united states of america"))
+	assert.False(t, wordPrefix("sta", "brazil"))
+}
+
+// Tests the relevance of each kind of match
+func TestMatchName(t *testing.T) {
+	tests := []struct {
+		query string
+		name  string
+		rank  int
+		ok    bool
+	}{
+		{"brazil", "Brazil", 0, true},
+		{"bra", "Brazil", 1, true},
+		{"states", "United States of America", 2, true},
+		{"ited", "United States of America", 3, true},
+		{"ited states", "United States of America", 3, true},
+		{"tes", "United States of America", 3, true},
+		{"brazl", "Brazil", 4, true},
+		{"untied states", "United States of America", 5, true},
+		{"curacao", "Curaçao", 0, true},
+		{"xyz", "Brazil", 0, false},
+		{"", "Brazil", 0, false},
+	}
+	for _, tt := range tests {
+		rank, ok := MatchName(tt.query, tt.name)
+		assert.Equal(t, tt.ok, ok, tt.query)
+		if tt.ok {
+			assert.Equal(t, tt.rank, rank, tt.query)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Adoção de uma vacina (ou de um fabricante) por um país, na primeira data de
+// início de uso no país
+type adoption struct {
+	country   string
+	startDate string // YYYY-MM-DD
+}
+
+// Lê a lista de mapas {country, startDate} retornada pelas queries; itens sem
+// data de início são ignorados
+func adoptionsFromRecord(value interface{}) []adoption {
+	items, _ := value.([]interface{})
+	var adoptions []adoption
+	for _, item := range items {
+		fields, _ := item.(map[string]interface{})
+		startDate, _ := fields["startDate"].(string)
+		if startDate == "" {
+			continue
+		}
+		adoptions = append(adoptions, adoption{country: fmt.Sprint(fields["country"]), startDate: startDate})
+	}
+	return adoptions
+}
+
+// Agrupa as adoções por mês, do primeiro ao último mês com adoções. Meses sem
+// novas adoções também entram, para que a curva acumulada não tenha lacunas
+func adoptionTimeline(adoptions []adoption) []map[string]interface{} {
+	timeline := []map[string]interface{}{}
+	if len(adoptions) == 0 {
+		return timeline
+	}
+
+	sorted := append([]adoption(nil), adoptions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].startDate != sorted[j].startDate {
+			return sorted[i].startDate < sorted[j].startDate
+		}
+		return sorted[i].country < sorted[j].country
+	})
+
+	month, _ := time.Parse("2006-01", sorted[0].startDate[:7])
+	cumulative := 0
+	for i := 0; i < len(sorted); month = month.AddDate(0, 1, 0) {
+		key := month.Format("2006-01")
+		countries := []string{}
+		for ; i < len(sorted) && sorted[i].startDate[:7] == key; i++ {
+			countries = append(countries, sorted[i].country)
+		}
+		cumulative += len(countries)
+		timeline = append(timeline, map[string]interface{}{
+			"month":               key,
+			"countries":           countries,
+			"newCountries":        len(countries),
+			"cumulativeCountries": cumulative,
+		})
+	}
+	return timeline
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"desafiogolang-neo4j/analytics"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Métricas aceitas pelas análises de série e a propriedade correspondente
+var seriesMetrics = map[string]string{
+	"newCases":  "cs.newCases",
+	"newDeaths": "cs.newDeaths",
+}
+
+// Distância mínima, em dias, entre dois picos locais
+const peakDistance = 28
+
+// Parâmetros das análises de série
+type analyticsParams struct {
+	metric       string
+	from, to     string
+	windows      []int
+	growthWindow int
+}
+
+// Lê os parâmetros metric, from, to, windows e growthWindow; em caso de erro
+// a resposta já foi escrita e ok é falso
+func parseAnalyticsParams(w http.ResponseWriter, r *http.Request) (params analyticsParams, ok bool) {
+	params.metric = r.URL.Query().Get("metric")
+	if params.metric == "" {
+		params.metric = "newCases"
+	}
+	if _, found := seriesMetrics[params.metric]; !found {
+		http.Error(w, "Invalid 'metric' parameter", http.StatusBadRequest)
+		return params, false
+	}
+
+	if params.from, params.to, ok = dateRange(w, r); !ok {
+		return params, false
+	}
+
+	windows := r.URL.Query().Get("windows")
+	if windows == "" {
+		windows = "7,14,28"
+	}
+	for _, value := range strings.Split(windows, ",") {
+		days, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || days <= 0 {
+			http.Error(w, "Invalid 'windows' parameter", http.StatusBadRequest)
+			return params, false
+		}
+		params.windows = append(params.windows, days)
+	}
+	sort.Ints(params.windows)
+
+	if params.growthWindow, ok = intParam(w, r, "growthWindow", 7, 1); !ok {
+		return params, false
+	}
+	return params, true
+}
+
+// Executa uma consulta que retorna as colunas date (YYYY-MM-DD) e value em
+// ordem de data e monta a série
+func fetchSeries(ctx context.Context, session neo4j.SessionWithContext, query string, params map[string]interface{}) (analytics.Series, error) {
+	result, err := session.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var series analytics.Series
+	for result.Next(ctx) {
+		record := result.Record()
+		dateValue, _ := record.Get("date")
+		date, err := time.Parse("2006-01-02", fmt.Sprint(dateValue))
+		if err != nil {
+			return nil, err
+		}
+		value, _ := record.Get("value")
+		var v float64
+		switch n := value.(type) {
+		case int64:
+			v = float64(n)
+		case float64:
+			v = n
+		}
+		series = append(series, analytics.Point{Date: date, Value: v})
+	}
+	return series, result.Err()
+}
+
+// Calcula as análises da série e escreve a resposta; apenas os pontos a
+// partir de from são retornados, mas os anteriores entram nas janelas
+func writeAnalytics(w http.ResponseWriter, series analytics.Series, params analyticsParams, subject map[string]interface{}) {
+	rollingMeans := make([][]*float64, len(params.windows))
+	for i, days := range params.windows {
+		rollingMeans[i] = analytics.RollingMean(series, days)
+	}
+	percentChanges := analytics.PercentChange(series, params.growthWindow)
+	growthRatios := analytics.GrowthRatio(series, params.growthWindow)
+	doublingTimes := analytics.DoublingTime(series, params.growthWindow)
+
+	var points []map[string]interface{}
+	var visible analytics.Series
+	for i, p := range series {
+		date := p.Date.Format("2006-01-02")
+		if params.from != "" && date < params.from {
+			continue
+		}
+		point := map[string]interface{}{
+			"date":          date,
+			"value":         p.Value,
+			"percentChange": percentChanges[i],
+			"growthRatio":   growthRatios[i],
+			"doublingTime":  doublingTimes[i],
+		}
+		for j, days := range params.windows {
+			point[fmt.Sprintf("rollingMean%dd", days)] = rollingMeans[j][i]
+		}
+		points = append(points, point)
+		visible = append(visible, p)
+	}
+	if len(points) == 0 {
+		http.Error(w, "No data found", http.StatusNotFound)
+		return
+	}
+
+	peak, _ := analytics.Peak(visible)
+	var peaks []map[string]interface{}
+	for _, p := range analytics.Peaks(visible, peakDistance) {
+		peaks = append(peaks, map[string]interface{}{"date": p.Date.Format("2006-01-02"), "value": p.Value})
+	}
+
+	response := map[string]interface{}{
+		"metric":       params.metric,
+		"windows":      params.windows,
+		"growthWindow": params.growthWindow,
+		"points":       points,
+		"peak":         map[string]interface{}{"date": peak.Date.Format("2006-01-02"), "value": peak.Value},
+		"peaks":        peaks,
+	}
+	for key, value := range subject {
+		response[key] = value
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// Série da métrica nos relatórios do país até a data to
+func countrySeries(ctx context.Context, session neo4j.SessionWithContext, countryCode, metric, to string) (analytics.Series, error) {
+	return fetchSeries(ctx, session,
+		`MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+         WHERE $to = "" OR d.date <= date($to)
+         RETURN toString(d.date) AS date, coalesce(`+seriesMetrics[metric]+`, 0) AS value
+         ORDER BY d.date`,
+		map[string]interface{}{
+			"countryCode": countryCode,
+			"to":          to,
+		})
+}
+
+func countryAnalyticsHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, countryCode string) {
+	return func(w http.ResponseWriter, r *http.Request, countryCode string) {
+		params, ok := parseAnalyticsParams(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		series, err := countrySeries(ctx, session, countryCode, params.metric, params.to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+		writeAnalytics(w, series, params, map[string]interface{}{"country": countryCode})
+	}
+}
+
+func regionAnalyticsHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, region string) {
+	return func(w http.ResponseWriter, r *http.Request, region string) {
+		params, ok := parseAnalyticsParams(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// A série da região soma os relatórios dos seus países em cada data
+		series, err := fetchSeries(ctx, session,
+			`MATCH (:Region {name: $region})<-[:BELONGS]-(c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             WHERE $to = "" OR d.date <= date($to)
+             RETURN toString(d.date) AS date, sum(coalesce(`+seriesMetrics[params.metric]+`, 0)) AS value
+             ORDER BY date`,
+			map[string]interface{}{
+				"region": region,
+				"to":     params.to,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+		writeAnalytics(w, series, params, map[string]interface{}{"region": region})
+	}
+}
@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"desafiogolang-neo4j/countries"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Quantidade máxima de países em uma comparação
+const maxCompareCountries = 50
+
+// Métricas de casos e mortes do relatório cs do país c
+var compareCovidColumns = `cs.cumulativeCases AS cumulativeCases, cs.cumulativeDeaths AS cumulativeDeaths,
+                    cs.newCases AS newCases, cs.newDeaths AS newDeaths,
+                    ` + per100k("cs.cumulativeCases", "c.population") + ` AS cumulativeCasesPer100k,
+                    ` + per100k("cs.cumulativeDeaths", "c.population") + ` AS cumulativeDeathsPer100k,
+                    ` + caseFatalityRate("cs.cumulativeDeaths", "cs.cumulativeCases") + ` AS caseFatalityRate`
+
+// Métricas de vacinação do relatório vs
+var compareVaccinationColumns = `toString(vs.dateUpdated) AS vaccinationDate, vs.totalVaccinations AS totalVaccinations,
+                    vs.personsVaccinated1PlusDosePer100 AS personsVaccinated1PlusDosePer100,
+                    vs.personsLastDosePer100 AS personsLastDosePer100,
+                    vs.personsBoosterAddDosePer100 AS personsBoosterAddDosePer100`
+
+// Lê a lista de países separados por vírgula, normalizados para o código
+// alfa-3 e sem repetições
+func compareCountries(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	var codes []string
+	seen := map[string]bool{}
+	for _, value := range strings.Split(r.URL.Query().Get("countries"), ",") {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		code := countries.Canonical(value)
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		http.Error(w, "Missing 'countries' parameter", http.StatusBadRequest)
+		return nil, false
+	}
+	if len(codes) > maxCompareCountries {
+		http.Error(w, fmt.Sprintf("Too many countries, the maximum is %d", maxCompareCountries), http.StatusBadRequest)
+		return nil, false
+	}
+	return codes, true
+}
+
+func CompareHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		codes, ok := compareCountries(w, r)
+		if !ok {
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("date") != "" && (query.Get("from") != "" || query.Get("to") != "") {
+			http.Error(w, "'date' must not be combined with 'from' or 'to'", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		if query.Get("date") != "" {
+			compareOnDate(ctx, session, w, r, codes)
+		} else {
+			compareOnRange(ctx, session, w, r, codes)
+		}
+	}
+}
+
+// Uma linha por país, na ordem pedida: o relatório escolhido pelo modo match
+// e o relatório de vacinação mais recente até a data
+func compareOnDate(ctx context.Context, session neo4j.SessionWithContext, w http.ResponseWriter, r *http.Request, codes []string) {
+	date, match, ok := dateMatch(w, r)
+	if !ok {
+		return
+	}
+
+	where, orderBy := dateMatchClauses("d.date")
+	result, err := session.Run(ctx,
+		`UNWIND range(0, size($countryCodes) - 1) AS position
+         WITH position, $countryCodes[position] AS code
+         OPTIONAL MATCH (c:Country {code: code})
+         CALL {
+             WITH c
+             OPTIONAL MATCH (c)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             `+where+`
+             RETURN cs, d
+             `+orderBy+`
+             LIMIT 1
+         }
+         CALL {
+             WITH c
+             OPTIONAL MATCH (c)-[:VACCINATED_ON]->(vs:VaccinationStats)
+             WHERE vs.dateUpdated <= date($date)
+             RETURN vs
+             ORDER BY vs.dateUpdated DESC
+             LIMIT 1
+         }
+         RETURN code AS country, c.name AS name, toString(d.date) AS date,
+                `+compareCovidColumns+`,
+                `+compareVaccinationColumns+`
+         ORDER BY position`,
+		map[string]interface{}{
+			"countryCodes": codes,
+			"date":         date,
+			"match":        match,
+		})
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	var table []map[string]interface{}
+	found := false
+	for result.Next(ctx) {
+		row := result.Record().AsMap()
+		found = found || row["date"] != nil || row["vaccinationDate"] != nil
+		table = append(table, row)
+	}
+	if found {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"date":      date,
+			"match":     match,
+			"countries": table,
+		})
+	} else {
+		http.Error(w, "No data found", http.StatusNotFound)
+	}
+}
+
+// Uma linha por data de relatório no intervalo, com os valores de cada país
+// nessa data (nulos se o país não reportou) e a vacinação mais recente até ela
+func compareOnRange(ctx context.Context, session neo4j.SessionWithContext, w http.ResponseWriter, r *http.Request, codes []string) {
+	from, to, ok := dateRange(w, r)
+	if !ok {
+		return
+	}
+	params := map[string]interface{}{
+		"countryCodes": codes,
+		"from":         from,
+		"to":           to,
+	}
+
+	result, err := session.Run(ctx,
+		`UNWIND $countryCodes AS code
+         MATCH (c:Country {code: code})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+         WHERE ($from = "" OR d.date >= date($from)) AND ($to = "" OR d.date <= date($to))
+         RETURN c.code AS country, toString(d.date) AS date,
+                `+compareCovidColumns,
+		params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+	reports := map[string]map[string]map[string]interface{}{} // data -> país -> valores
+	for result.Next(ctx) {
+		row := result.Record().AsMap()
+		date, country := row["date"].(string), row["country"].(string)
+		delete(row, "date")
+		delete(row, "country")
+		if reports[date] == nil {
+			reports[date] = map[string]map[string]interface{}{}
+		}
+		reports[date][country] = row
+	}
+	if err := result.Err(); err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if len(reports) == 0 {
+		http.Error(w, "No data found", http.StatusNotFound)
+		return
+	}
+
+	result, err = session.Run(ctx,
+		`UNWIND $countryCodes AS code
+         MATCH (c:Country {code: code})-[:VACCINATED_ON]->(vs:VaccinationStats)
+         WHERE $to = "" OR vs.dateUpdated <= date($to)
+         RETURN c.code AS country, `+compareVaccinationColumns+`
+         ORDER BY vs.dateUpdated`,
+		params)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+	vaccinations := map[string][]map[string]interface{}{} // país -> relatórios em ordem de data
+	for result.Next(ctx) {
+		row := result.Record().AsMap()
+		country := row["country"].(string)
+		delete(row, "country")
+		vaccinations[country] = append(vaccinations[country], row)
+	}
+	if err := result.Err(); err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	dates := make([]string, 0, len(reports))
+	for date := range reports {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	table := make([]map[string]interface{}, len(dates))
+	for i, date := range dates {
+		values := map[string]interface{}{}
+		for _, country := range codes {
+			row := reports[date][country]
+			if row == nil {
+				row = map[string]interface{}{}
+			}
+			for _, vaccination := range vaccinations[country] {
+				if vaccination["vaccinationDate"].(string) > date {
+					break
+				}
+				for key, value := range vaccination {
+					row[key] = value
+				}
+			}
+			if len(row) == 0 {
+				values[country] = nil
+			} else {
+				values[country] = row
+			}
+		}
+		table[i] = map[string]interface{}{"date": date, "countries": values}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":      from,
+		"to":        to,
+		"countries": codes,
+		"rows":      table,
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"net/http"
+
+	"desafiogolang-neo4j/countries"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// CountryHandler atende as rotas /countries/{code} e /countries/{code}/...,
+// repassando o código do país já normalizado para o handler do recurso
+func CountryHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	detail := countryDetailHandler(driver)
+	covid := countryCovidHandler(driver)
+	vaccination := countryVaccinationHandler(driver)
+	analyticsSeries := countryAnalyticsHandler(driver)
+	waves := countryWavesHandler(driver)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := pathSegments(r.URL.Path, "/countries/")
+		if len(segments) == 0 || len(segments) > 2 {
+			http.NotFound(w, r)
+			return
+		}
+		countryCode := countries.Canonical(segments[0])
+		if len(segments) == 1 {
+			detail(w, r, countryCode)
+			return
+		}
+
+		switch segments[1] {
+		case "covid":
+			covid(w, r, countryCode)
+		case "vaccination":
+			vaccination(w, r, countryCode)
+		case "analytics":
+			analyticsSeries(w, r, countryCode)
+		case "waves":
+			waves(w, r, countryCode)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
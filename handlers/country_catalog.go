@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"desafiogolang-neo4j/countries"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func CountriesHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		region := r.URL.Query().Get("region")
+		query := r.URL.Query().Get("q")
+
+		limit, ok := intParam(w, r, "limit", 50, 1)
+		if !ok {
+			return
+		}
+		offset, ok := intParam(w, r, "offset", 0, 0)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx,
+			`MATCH (c:Country)
+             WHERE $region = "" OR (c)-[:BELONGS]->(:Region {name: $region})
+             RETURN c.code AS code, c.iso2 AS iso2, c.name AS name,
+                    head([(c)-[:BELONGS]->(r:Region) | r.name]) AS region, c.population AS population
+             ORDER BY name, code`,
+			map[string]interface{}{
+				"region": region,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		// A busca aceita o nome, com erros de digitação, ou qualquer código do
+		// país; os resultados vêm dos mais aos menos relevantes
+		type match struct {
+			country map[string]interface{}
+			rank    int
+		}
+		var matches []match
+		for result.Next(ctx) {
+			country := result.Record().AsMap()
+			if query == "" {
+				matches = append(matches, match{country: country})
+				continue
+			}
+			name, _ := country["name"].(string)
+			if rank, found := countries.MatchName(query, name); found {
+				matches = append(matches, match{country: country, rank: rank})
+			} else if country["code"] == countries.Canonical(query) || strings.EqualFold(fmt.Sprint(country["iso2"]), query) {
+				matches = append(matches, match{country: country})
+			}
+		}
+		if err := result.Err(); err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].rank < matches[j].rank })
+
+		page := []map[string]interface{}{}
+		for i := offset; i < len(matches) && i < offset+limit; i++ {
+			page = append(page, matches[i].country)
+		}
+		if len(matches) > 0 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"total":     len(matches),
+				"limit":     limit,
+				"offset":    offset,
+				"countries": page,
+			})
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
+
+func countryDetailHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, countryCode string) {
+	return func(w http.ResponseWriter, r *http.Request, countryCode string) {
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx,
+			`MATCH (c:Country {code: $countryCode})
+             CALL {
+                 WITH c
+                 OPTIONAL MATCH (c)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+                 WITH cs, d ORDER BY d.date
+                 RETURN toString(min(d.date)) AS firstReport, toString(max(d.date)) AS lastReport, count(cs) AS reports,
+                        collect(cs {date: toString(d.date), .cumulativeCases, .cumulativeDeaths, .newCases, .newDeaths})[-1] AS latestCovid
+             }
+             CALL {
+                 WITH c
+                 OPTIONAL MATCH (c)-[:VACCINATED_ON]->(vs:VaccinationStats)
+                 RETURN vs ORDER BY vs.dateUpdated DESC LIMIT 1
+             }
+             CALL {
+                 WITH c
+                 OPTIONAL MATCH (c)-[u:USES]->(v:Vaccine)
+                 WITH u, v ORDER BY u.startDate, v.product
+                 RETURN collect(CASE WHEN v IS NOT NULL THEN {vaccine: v.product, company: v.company,
+                                     startDate: toString(u.startDate), endDate: toString(u.endDate)} END) AS vaccines
+             }
+             RETURN c.code AS code, c.iso2 AS iso2, c.iso3 AS iso3, c.numeric AS numeric, c.name AS name,
+                    head([(c)-[:BELONGS]->(r:Region) | r.name]) AS region, c.population AS population,
+                    firstReport, lastReport, reports,
+                    head([(c)-[:FIRST_VACCINATED_ON]->(fv:Date) | toString(fv.date)]) AS firstVaccinationDate,
+                    vaccines, latestCovid,
+                    vs {dateUpdated: toString(vs.dateUpdated), .totalVaccinations, .personsVaccinated1PlusDose,
+                        .personsVaccinated1PlusDosePer100, .personsLastDose, .personsLastDosePer100,
+                        .personsBoosterAddDose, .personsBoosterAddDosePer100} AS latestVaccination`,
+			map[string]interface{}{
+				"countryCode": countryCode,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if result.Next(ctx) {
+			json.NewEncoder(w).Encode(result.Record().AsMap())
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
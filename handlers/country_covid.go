@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Períodos aceitos pelo parâmetro interval; "report" mantém as datas dos relatórios
+var intervals = map[string]bool{"report": true, "week": true, "month": true}
+
+func countryCovidHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, countryCode string) {
+	return func(w http.ResponseWriter, r *http.Request, countryCode string) {
+		from, to, ok := dateRange(w, r)
+		if !ok {
+			return
+		}
+		interval := r.URL.Query().Get("interval")
+		if interval == "" {
+			interval = "report"
+		}
+		if !intervals[interval] {
+			http.Error(w, "Invalid 'interval' parameter", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// Na agregação, os casos novos são somados e os acumulados são os do
+		// último relatório do período
+		result, err := session.Run(ctx,
+			`MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             WHERE ($from = "" OR d.date >= date($from)) AND ($to = "" OR d.date <= date($to))
+             WITH c, cs, d ORDER BY d.date
+             WITH c, cs, CASE $interval
+                             WHEN "week" THEN date.truncate("week", d.date)
+                             WHEN "month" THEN date.truncate("month", d.date)
+                             ELSE d.date
+                         END AS period
+             WITH c, period, sum(cs.newCases) AS newCases, sum(cs.newDeaths) AS newDeaths,
+                  collect(cs.cumulativeCases)[-1] AS cumulativeCases, collect(cs.cumulativeDeaths)[-1] AS cumulativeDeaths
+             RETURN toString(period) AS date, newCases, newDeaths, cumulativeCases, cumulativeDeaths,
+                    `+per100k("newCases", "c.population")+` AS newCasesPer100k,
+                    `+per100k("newDeaths", "c.population")+` AS newDeathsPer100k,
+                    `+per100k("cumulativeCases", "c.population")+` AS cumulativeCasesPer100k,
+                    `+per100k("cumulativeDeaths", "c.population")+` AS cumulativeDeathsPer100k,
+                    `+caseFatalityRate("cumulativeDeaths", "cumulativeCases")+` AS caseFatalityRate
+             ORDER BY period`,
+			map[string]interface{}{
+				"countryCode": countryCode,
+				"from":        from,
+				"to":          to,
+				"interval":    interval,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var series []map[string]interface{}
+		for result.Next(ctx) {
+			series = append(series, result.Record().AsMap())
+		}
+		if len(series) > 0 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"country":  countryCode,
+				"interval": interval,
+				"series":   series,
+			})
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func countryVaccinationHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, countryCode string) {
+	return func(w http.ResponseWriter, r *http.Request, countryCode string) {
+		from, to, ok := dateRange(w, r)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// Cada exportação da OMS acrescenta um VaccinationStats por data de
+		// atualização, então a série é o histórico dos relatórios do país
+		result, err := session.Run(ctx,
+			`MATCH (c:Country {code: $countryCode})-[:VACCINATED_ON]->(vs:VaccinationStats)
+             WHERE ($from = "" OR vs.dateUpdated >= date($from)) AND ($to = "" OR vs.dateUpdated <= date($to))
+             RETURN toString(vs.dateUpdated) AS date,
+                    vs.totalVaccinations AS totalVaccinations, vs.totalVaccinationsPer100 AS totalVaccinationsPer100,
+                    vs.personsVaccinated1PlusDose AS personsVaccinated1PlusDose,
+                    vs.personsVaccinated1PlusDosePer100 AS personsVaccinated1PlusDosePer100,
+                    vs.personsLastDose AS personsLastDose, vs.personsLastDosePer100 AS personsLastDosePer100,
+                    vs.personsBoosterAddDose AS personsBoosterAddDose, vs.personsBoosterAddDosePer100 AS personsBoosterAddDosePer100,
+                    vs.numberVaccineTypesUsed AS numberVaccineTypesUsed
+             ORDER BY vs.dateUpdated`,
+			map[string]interface{}{
+				"countryCode": countryCode,
+				"from":        from,
+				"to":          to,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var series []map[string]interface{}
+		for result.Next(ctx) {
+			series = append(series, result.Record().AsMap())
+		}
+		if len(series) > 0 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"country": countryCode,
+				"series":  series,
+			})
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
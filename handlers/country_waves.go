@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"desafiogolang-neo4j/analytics"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Lê um parâmetro opcional de fração entre 0 e 1; em caso de erro a resposta
+// já foi escrita e ok é falso
+func shareParam(w http.ResponseWriter, r *http.Request, name string, def float64) (share float64, ok bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, true
+	}
+	share, err := strconv.ParseFloat(value, 64)
+	if err != nil || share < 0 || share > 1 {
+		http.Error(w, fmt.Sprintf("Invalid '%s' parameter", name), http.StatusBadRequest)
+		return 0, false
+	}
+	return share, true
+}
+
+func countryWavesHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, countryCode string) {
+	return func(w http.ResponseWriter, r *http.Request, countryCode string) {
+		metric := r.URL.Query().Get("metric")
+		if metric == "" {
+			metric = "newCases"
+		}
+		if _, found := seriesMetrics[metric]; !found {
+			http.Error(w, "Invalid 'metric' parameter", http.StatusBadRequest)
+			return
+		}
+		from, to, ok := dateRange(w, r)
+		if !ok {
+			return
+		}
+
+		opts := analytics.DefaultWaveOptions()
+		if opts.MinDistance, ok = intParam(w, r, "minDistance", opts.MinDistance, 1); !ok {
+			return
+		}
+		if opts.MinPeakShare, ok = shareParam(w, r, "minPeakShare", opts.MinPeakShare); !ok {
+			return
+		}
+		if opts.MaxTroughShare, ok = shareParam(w, r, "maxTroughShare", opts.MaxTroughShare); !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		series, err := countrySeries(ctx, session, countryCode, metric, to)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for len(series) > 0 && from != "" && series[0].Date.Format("2006-01-02") < from {
+			series = series[1:]
+		}
+		if len(series) == 0 {
+			http.Error(w, "No data found", http.StatusNotFound)
+			return
+		}
+
+		waves := []map[string]interface{}{}
+		for i, wave := range analytics.Waves(series, opts) {
+			waves = append(waves, map[string]interface{}{
+				"wave":      i + 1,
+				"startDate": wave.Start.Format("2006-01-02"),
+				"peakDate":  wave.Peak.Format("2006-01-02"),
+				"endDate":   wave.End.Format("2006-01-02"),
+				"peakValue": wave.PeakValue,
+				"total":     wave.Total,
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"country": countryCode,
+			"metric":  metric,
+			"waves":   waves,
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Totais mundiais da série de /global, a partir dos relatórios em ordem de
+// data. Como nem todo país reporta toda semana, os acumulados de um país que
+// não reportou na data são os do seu último relatório.
+type globalTotals struct {
+	cases  map[string]int64 // Último acumulado de casos de cada país
+	deaths map[string]int64
+
+	cumulativeCases  int64
+	cumulativeDeaths int64
+
+	// Relatórios da data corrente
+	date               string
+	newCases           int64
+	newDeaths          int64
+	reportingCountries int
+}
+
+func newGlobalTotals() *globalTotals {
+	return &globalTotals{cases: map[string]int64{}, deaths: map[string]int64{}}
+}
+
+// Acrescenta o relatório de um país; os relatórios devem vir em ordem de data
+func (g *globalTotals) add(country, date string, cumulativeCases, cumulativeDeaths, newCases, newDeaths int64) {
+	if date != g.date {
+		g.date, g.newCases, g.newDeaths, g.reportingCountries = date, 0, 0, 0
+	}
+	g.cumulativeCases += cumulativeCases - g.cases[country]
+	g.cumulativeDeaths += cumulativeDeaths - g.deaths[country]
+	g.cases[country], g.deaths[country] = cumulativeCases, cumulativeDeaths
+	g.newCases += newCases
+	g.newDeaths += newDeaths
+	g.reportingCountries++
+}
+
+// Totais na data corrente da série
+func (g *globalTotals) current() map[string]interface{} {
+	totals := map[string]interface{}{
+		"date":                    g.date,
+		"cumulativeCases":         g.cumulativeCases,
+		"cumulativeDeaths":        g.cumulativeDeaths,
+		"newCases":                g.newCases,
+		"newDeaths":               g.newDeaths,
+		"reportingCountries":      g.reportingCountries,
+		"carriedForwardCountries": len(g.cases) - g.reportingCountries,
+		"caseFatalityRate":        nil,
+	}
+	if g.cumulativeCases > 0 {
+		totals["caseFatalityRate"] = float64(g.cumulativeDeaths) * 100 / float64(g.cumulativeCases)
+	}
+	return totals
+}
+
+func GlobalHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
+		from, to, ok := dateRange(w, r)
+		if !ok {
+			return
+		}
+		if date != "" && (from != "" || to != "") {
+			http.Error(w, "'date' must not be combined with 'from' or 'to'", http.StatusBadRequest)
+			return
+		}
+
+		if date != "" {
+			globalOnDate(w, driver, date)
+		} else {
+			globalOnRange(w, driver, from, to)
+		}
+	}
+}
+
+// Totais em uma única data, mesmo sem relatórios nela. O último relatório de
+// cada país até a data é escolhido e somado no banco.
+func globalOnDate(w http.ResponseWriter, driver neo4j.DriverWithContext, date string) {
+	ctx := context.Background()
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	result, err := session.Run(ctx,
+		`MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+         WHERE d.date <= date($date)
+         WITH c, cs, d ORDER BY d.date DESC
+         WITH c, collect({cs: cs, reported: d.date = date($date)})[0] AS latest
+         WITH count(c) AS countries,
+              sum(coalesce(latest.cs.cumulativeCases, 0)) AS cumulativeCases,
+              sum(coalesce(latest.cs.cumulativeDeaths, 0)) AS cumulativeDeaths,
+              sum(CASE WHEN latest.reported THEN coalesce(latest.cs.newCases, 0) ELSE 0 END) AS newCases,
+              sum(CASE WHEN latest.reported THEN coalesce(latest.cs.newDeaths, 0) ELSE 0 END) AS newDeaths,
+              count(CASE WHEN latest.reported THEN 1 END) AS reportingCountries
+         WHERE countries > 0
+         RETURN $date AS date, cumulativeCases, cumulativeDeaths, newCases, newDeaths, reportingCountries,
+                countries - reportingCountries AS carriedForwardCountries,
+                CASE WHEN cumulativeCases > 0 THEN toFloat(cumulativeDeaths) * 100 / cumulativeCases END AS caseFatalityRate`,
+		map[string]interface{}{
+			"date": date,
+		})
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if result.Next(ctx) {
+		json.NewEncoder(w).Encode(result.Record().AsMap())
+	} else {
+		http.Error(w, "No data found", http.StatusNotFound)
+	}
+}
+
+// Totais de cada data de relatório do intervalo. Além dos relatórios do
+// intervalo, o banco devolve apenas o último relatório de cada país antes de
+// from, usado como ponto de partida dos acumulados.
+func globalOnRange(w http.ResponseWriter, driver neo4j.DriverWithContext, from, to string) {
+	ctx := context.Background()
+	session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer session.Close(ctx)
+
+	start := from
+	if start == "" {
+		start = "0001-01-01"
+	}
+	result, err := session.Run(ctx,
+		`CALL {
+             MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             WHERE d.date < date($start)
+             WITH c, cs, d ORDER BY d.date DESC
+             WITH c, collect({cs: cs, date: d.date})[0] AS latest
+             RETURN c.code AS country, latest.date AS date, latest.cs AS cs
+             UNION ALL
+             MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             WHERE d.date >= date($start) AND ($to = "" OR d.date <= date($to))
+             RETURN c.code AS country, d.date AS date, cs
+         }
+         RETURN country, toString(date) AS date,
+                coalesce(cs.cumulativeCases, 0) AS cumulativeCases, coalesce(cs.cumulativeDeaths, 0) AS cumulativeDeaths,
+                coalesce(cs.newCases, 0) AS newCases, coalesce(cs.newDeaths, 0) AS newDeaths
+         ORDER BY date`,
+		map[string]interface{}{
+			"start": start,
+			"to":    to,
+		})
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	totals := newGlobalTotals()
+	var series []map[string]interface{}
+	for result.Next(ctx) {
+		record := result.Record()
+		country, _ := record.Values[0].(string)
+		reportDate, _ := record.Values[1].(string)
+		if reportDate != totals.date && totals.date >= from && totals.date != "" {
+			series = append(series, totals.current())
+		}
+		cumulativeCases, _ := record.Values[2].(int64)
+		cumulativeDeaths, _ := record.Values[3].(int64)
+		newCases, _ := record.Values[4].(int64)
+		newDeaths, _ := record.Values[5].(int64)
+		totals.add(country, reportDate, cumulativeCases, cumulativeDeaths, newCases, newDeaths)
+	}
+	if err := result.Err(); err != nil {
+		http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if totals.date != "" && totals.date >= from {
+		series = append(series, totals.current())
+	}
+	if len(series) > 0 {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"from":   from,
+			"to":     to,
+			"series": series,
+		})
+	} else {
+		http.Error(w, "No data found", http.StatusNotFound)
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func ImportRunsHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dataset := r.URL.Query().Get("dataset")
+
+		limit, ok := intParam(w, r, "limit", 50, 1)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx,
+			`MATCH (i:ImportRun)
+             WHERE $dataset = "" OR i.dataset = $dataset
+             RETURN i.id AS id, i.dataset AS dataset, i.source AS source, i.sha256 AS sha256, i.status AS status,
+                    toString(i.startedAt) AS startedAt, toString(i.finishedAt) AS finishedAt, i.watermark AS watermark,
+                    i.rowsRead AS rowsRead, i.rowsWritten AS rowsWritten, i.rowsRejected AS rowsRejected, i.rowsSkipped AS rowsSkipped
+             ORDER BY i.startedAt DESC
+             LIMIT $limit`,
+			map[string]interface{}{
+				"dataset": dataset,
+				"limit":   limit,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var runs []map[string]interface{}
+		for result.Next(ctx) {
+			runs = append(runs, result.Record().AsMap())
+		}
+		if len(runs) > 0 {
+			json.NewEncoder(w).Encode(runs)
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
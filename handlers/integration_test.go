@@ -66,6 +66,102 @@ func TestTotalCasesDeathsHandler(t *testing.T) {
 	teardownTestData(driver)
 }
 
+// Tests that the ISO3 code resolves to the same country as the ISO2 code
+func TestTotalCasesDeathsHandler_ISO3Code(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/total-cases-deaths?country=USA&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := TotalCasesDeathsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1000), response["totalCumulativeCases"])
+
+	teardownTestData(driver)
+}
+
+// Tests the per capita values and the case fatality rate in the CasesDeaths endpoint
+func TestTotalCasesDeathsHandler_PerCapita(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/total-cases-deaths?country=US&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := TotalCasesDeathsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(331000000), response["population"])
+	assert.InDelta(t, 1000*100000.0/331000000, response["cumulativeCasesPer100k"], 1e-9)
+	assert.InDelta(t, 50*100000.0/331000000, response["cumulativeDeathsPer100k"], 1e-9)
+	assert.InDelta(t, 5.0, response["caseFatalityRate"], 1e-9)
+
+	teardownTestData(driver)
+}
+
+// Tests the report chosen by each match mode on a day without a report
+func TestTotalCasesDeathsHandler_Match(t *testing.T) {
+	setupTestData(driver)
+
+	handler := TotalCasesDeathsHandler(driver)
+	tests := []struct {
+		match string
+		date  string
+		cases float64
+	}{
+		{"previous", "2021-11-24", 900},
+		{"next", "2021-12-01", 1000},
+		{"nearest", "2021-12-01", 1000},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/total-cases-deaths?country=US&date=2021-11-29&match="+tt.match, nil)
+		w := httptest.NewRecorder()
+		handler(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode, tt.match)
+
+		var response map[string]interface{}
+		err := json.NewDecoder(w.Body).Decode(&response)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tt.date, response["date"], tt.match)
+		assert.Equal(t, tt.cases, response["totalCumulativeCases"], tt.match)
+	}
+
+	// The default mode only accepts a report on the exact date
+	req := httptest.NewRequest("GET", "/total-cases-deaths?country=US&date=2021-11-29", nil)
+	w := httptest.NewRecorder()
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+
+	teardownTestData(driver)
+}
+
+// Tests an invalid match mode in the CasesDeaths endpoint
+func TestTotalCasesDeathsHandler_InvalidMatch(t *testing.T) {
+	req := httptest.NewRequest("GET", "/total-cases-deaths?country=US&date=2021-11-29&match=closest", nil)
+	w := httptest.NewRecorder()
+
+	handler := TotalCasesDeathsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'match' parameter\n", w.Body.String())
+}
+
 // Tests a successful return value in the Vaccinated endpoint
 func TestVaccinatedHandler(t *testing.T) {
 	setupTestData(driver)
@@ -87,6 +183,28 @@ func TestVaccinatedHandler(t *testing.T) {
 	teardownTestData(driver)
 }
 
+// Tests the nearest vaccination report in the Vaccinated endpoint
+func TestVaccinatedHandler_Nearest(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/vaccinated?country=USA&date=2021-11-25&match=nearest", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccinatedHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "2021-11-24", response["date"])
+	assert.Equal(t, float64(450), response["totalVaccinated"])
+
+	teardownTestData(driver)
+}
+
 // Tests a successful return value in the VaccinesUsed endpoint
 func TestVaccinesUsedHandler(t *testing.T) {
 	setupTestData(driver)
@@ -110,6 +228,37 @@ func TestVaccinesUsedHandler(t *testing.T) {
 	teardownTestData(driver)
 }
 
+// Tests the vaccines in use on a given date in the VaccinesUsed endpoint
+func TestVaccinesUsedHandler_Date(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/vaccines-used?country=USA&date=2021-06-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccinesUsedHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response []map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Len(t, response, 1)
+	assert.Equal(t, "Pfizer", response[0]["vaccine"])
+	assert.Nil(t, response[0]["endDate"])
+	assert.Equal(t, "REPORTING", response[0]["dataSource"])
+
+	// Before the start date no vaccine was in use
+	req = httptest.NewRequest("GET", "/vaccines-used?country=USA&date=2020-06-01", nil)
+	w = httptest.NewRecorder()
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+
+	teardownTestData(driver)
+}
+
 // Tests a successful return value in the HighestCases endpoint
 func TestHighestCasesHandler(t *testing.T) {
 	setupTestData(driver)
@@ -126,7 +275,7 @@ func TestHighestCasesHandler(t *testing.T) {
 	err := json.NewDecoder(w.Body).Decode(&response)
 	assert.NoError(t, err)
 
-	assert.Equal(t, "US", response["country"])
+	assert.Equal(t, "USA", response["country"])
 	assert.Equal(t, float64(1000), response["cases"])
 
 	teardownTestData(driver)
@@ -228,10 +377,967 @@ func TestMostUsedVaccineHandler(t *testing.T) {
 	// Assert if the values are returned correctly
 	assert.Equal(t, "Pfizer", response["vaccine"])
 	assert.Equal(t, float64(1), response["usage"])
+	assert.Equal(t, true, response["tie"])
+	assert.Equal(t, []interface{}{"Pfizer", "Sinovac"}, response["vaccines"])
+
+	teardownTestData(driver)
+}
+
+// Test the most used vaccine among the vaccines in use on a date
+func TestMostUsedVaccineHandler_Date(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/most-used-vaccine?region=Americas&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := MostUsedVaccineHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Pfizer", response["vaccine"])
+	assert.Equal(t, false, response["tie"])
+
+	teardownTestData(driver)
+}
+
+// Test the full vaccine distribution of a region
+func TestVaccineDistributionHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/vaccine-distribution?region=Americas", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccineDistributionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["countries"])
+	vaccines := response["vaccines"].([]interface{})
+	assert.Len(t, vaccines, 2)
+	pfizer := vaccines[0].(map[string]interface{})
+	assert.Equal(t, "Pfizer", pfizer["vaccine"])
+	assert.Equal(t, float64(1), pfizer["rank"])
+	assert.Equal(t, 0.5, pfizer["share"])
+	assert.Equal(t, []interface{}{"USA"}, pfizer["countries"])
+	assert.Equal(t, true, pfizer["tied"])
+	assert.Equal(t, float64(1), vaccines[1].(map[string]interface{})["rank"])
+
+	teardownTestData(driver)
+}
+
+// Test the import runs endpoint filtered by dataset
+func TestImportRunsHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/import-runs?dataset=global-data", nil)
+	w := httptest.NewRecorder()
+
+	handler := ImportRunsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response []map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Len(t, response, 1)
+	assert.Equal(t, "test-run", response[0]["id"])
+	assert.Equal(t, "completed", response[0]["status"])
+	assert.Equal(t, "2021-12-01", response[0]["watermark"])
+	assert.Equal(t, float64(1), response[0]["rowsWritten"])
+
+	teardownTestData(driver)
+}
+
+// Test an invalid limit in the import runs endpoint
+func TestImportRunsHandler_InvalidLimit(t *testing.T) {
+	req := httptest.NewRequest("GET", "/import-runs?limit=abc", nil)
+	w := httptest.NewRecorder()
+
+	handler := ImportRunsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'limit' parameter\n", w.Body.String())
+}
+
+// Test the weekly reports returned by the country covid series endpoint
+func TestCountryHandler_Covid(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/US/covid?from=2021-11-01&to=2021-12-31", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "USA", response["country"])
+	assert.Equal(t, "report", response["interval"])
+	series := response["series"].([]interface{})
+	assert.Len(t, series, 2)
+	assert.Equal(t, "2021-11-24", series[0].(map[string]interface{})["date"])
+	assert.Equal(t, float64(80), series[0].(map[string]interface{})["newCases"])
+	assert.Equal(t, float64(1000), series[1].(map[string]interface{})["cumulativeCases"])
+
+	teardownTestData(driver)
+}
+
+// Test the monthly aggregation of the country covid series endpoint
+func TestCountryHandler_CovidMonthly(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/USA/covid?interval=month", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	series := response["series"].([]interface{})
+	assert.Len(t, series, 2)
+	november := series[0].(map[string]interface{})
+	assert.Equal(t, "2021-11-01", november["date"])
+	assert.Equal(t, float64(900), november["cumulativeCases"])
+	december := series[1].(map[string]interface{})
+	assert.Equal(t, "2021-12-01", december["date"])
+	assert.Equal(t, float64(100), december["newCases"])
+
+	teardownTestData(driver)
+}
+
+// Test an invalid interval in the country covid series endpoint
+func TestCountryHandler_CovidInvalidInterval(t *testing.T) {
+	req := httptest.NewRequest("GET", "/countries/US/covid?interval=year", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'interval' parameter\n", w.Body.String())
+}
+
+// Test the vaccination history returned by the country vaccination endpoint
+func TestCountryHandler_Vaccination(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/US/vaccination?from=2021-11-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	series := response["series"].([]interface{})
+	assert.Len(t, series, 2)
+	previous := series[0].(map[string]interface{})
+	assert.Equal(t, "2021-11-24", previous["date"])
+	assert.Equal(t, float64(450), previous["personsVaccinated1PlusDose"])
+	assert.Equal(t, 0.14, previous["personsVaccinated1PlusDosePer100"])
+	assert.Equal(t, float64(500), series[1].(map[string]interface{})["totalVaccinations"])
+
+	teardownTestData(driver)
+}
+
+// Test an invalid date range in the country vaccination endpoint
+func TestCountryHandler_VaccinationInvalidRange(t *testing.T) {
+	req := httptest.NewRequest("GET", "/countries/US/vaccination?from=2021-12-01&to=2021-11-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "'from' must not be after 'to'\n", w.Body.String())
+}
+
+// Test the countries ranked by cumulative deaths
+func TestRankingHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/ranking?metric=cumulativeDeaths&date=2021-12-01&region=Americas", nil)
+	w := httptest.NewRecorder()
+
+	handler := RankingHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["total"])
+	ranking := response["ranking"].([]interface{})
+	assert.Len(t, ranking, 2)
+	first := ranking[0].(map[string]interface{})
+	assert.Equal(t, float64(1), first["rank"])
+	assert.Equal(t, "CAN", first["country"])
+	assert.Equal(t, float64(60), first["value"])
+	assert.Equal(t, "Americas", first["region"])
+
+	teardownTestData(driver)
+}
+
+// Test the ranking by a per capita metric
+func TestRankingHandler_PerCapita(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/ranking?metric=cumulativeCasesPer100k&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := RankingHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	ranking := response["ranking"].([]interface{})
+	first := ranking[0].(map[string]interface{})
+	assert.Equal(t, "CAN", first["country"])
+	assert.InDelta(t, 300*100000.0/38000000, first["value"], 1e-9)
 
 	teardownTestData(driver)
 }
 
+// Test the ascending order and pagination of the ranking
+func TestRankingHandler_AscendingOffset(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/ranking?metric=cumulativeCases&date=2021-12-01&order=asc&limit=1&offset=1", nil)
+	w := httptest.NewRecorder()
+
+	handler := RankingHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	ranking := response["ranking"].([]interface{})
+	assert.Len(t, ranking, 1)
+	assert.Equal(t, "USA", ranking[0].(map[string]interface{})["country"])
+	assert.Equal(t, float64(2), ranking[0].(map[string]interface{})["rank"])
+
+	teardownTestData(driver)
+}
+
+// Test an unknown metric in the ranking
+func TestRankingHandler_InvalidMetric(t *testing.T) {
+	req := httptest.NewRequest("GET", "/ranking?metric=population&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := RankingHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'metric' parameter\n", w.Body.String())
+}
+
+// Test the listing of regions with their number of countries
+func TestRegionsHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/regions", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionsHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response []map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Contains(t, response, map[string]interface{}{"name": "Americas", "countries": float64(2)})
+
+	teardownTestData(driver)
+}
+
+// Test the aggregated statistics of a region on a date
+func TestRegionHandler_Summary(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/regions/Americas/summary?date=2021-12-01&top=1", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["countries"])
+	assert.Equal(t, float64(2), response["reportingCountries"])
+	assert.Equal(t, float64(1300), response["cumulativeCases"])
+	assert.Equal(t, float64(120), response["newCases"])
+	assert.Equal(t, float64(369000000), response["population"])
+	assert.InDelta(t, 1300*100000.0/369000000, response["cumulativeCasesPer100k"], 1e-9)
+	assert.InDelta(t, 110*100.0/1300, response["caseFatalityRate"], 1e-9)
+	// Coverage is weighted by the population of the country
+	assert.InDelta(t, 500*100.0/331000000, response["personsVaccinated1PlusDosePer100"], 1e-9)
+
+	top := response["topContributors"].([]interface{})
+	assert.Len(t, top, 1)
+	assert.Equal(t, "USA", top[0].(map[string]interface{})["country"])
+	assert.InDelta(t, 100.0/120, top[0].(map[string]interface{})["share"], 1e-9)
+
+	teardownTestData(driver)
+}
+
+// Test a region without countries in the region summary
+func TestRegionHandler_SummaryUnknownRegion(t *testing.T) {
+	req := httptest.NewRequest("GET", "/regions/Atlantis/summary?date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	assert.Equal(t, "No data found\n", w.Body.String())
+}
+
+// Test the world totals on a report date
+func TestGlobalHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/global?date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := GlobalHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(1300), response["cumulativeCases"])
+	assert.Equal(t, float64(110), response["cumulativeDeaths"])
+	assert.Equal(t, float64(120), response["newCases"])
+	assert.Equal(t, float64(2), response["reportingCountries"])
+	assert.Equal(t, float64(0), response["carriedForwardCountries"])
+
+	teardownTestData(driver)
+}
+
+// Test that the cumulative totals of a country are carried forward between reports
+func TestGlobalHandler_CarryForward(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/global?date=2021-11-28", nil)
+	w := httptest.NewRecorder()
+
+	handler := GlobalHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(900), response["cumulativeCases"])
+	assert.Equal(t, float64(0), response["newCases"])
+	assert.Equal(t, float64(0), response["reportingCountries"])
+	assert.Equal(t, float64(1), response["carriedForwardCountries"])
+
+	teardownTestData(driver)
+}
+
+// Test the world totals of every report date in a range
+func TestGlobalHandler_Range(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/global?from=2021-11-25&to=2021-12-31", nil)
+	w := httptest.NewRecorder()
+
+	handler := GlobalHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	series := response["series"].([]interface{})
+	assert.Len(t, series, 1)
+	assert.Equal(t, "2021-12-01", series[0].(map[string]interface{})["date"])
+	assert.Equal(t, float64(1300), series[0].(map[string]interface{})["cumulativeCases"])
+
+	teardownTestData(driver)
+}
+
+// Test that a single date cannot be combined with a range in the world totals
+func TestGlobalHandler_DateWithRange(t *testing.T) {
+	req := httptest.NewRequest("GET", "/global?date=2021-12-01&from=2021-11-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := GlobalHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "'date' must not be combined with 'from' or 'to'\n", w.Body.String())
+}
+
+// Test the rolling means and growth of the country analytics endpoint
+func TestCountryHandler_Analytics(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/US/analytics?windows=7", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "USA", response["country"])
+	points := response["points"].([]interface{})
+	assert.Len(t, points, 2)
+	first := points[0].(map[string]interface{})
+	assert.Nil(t, first["rollingMean7d"])
+	last := points[1].(map[string]interface{})
+	assert.InDelta(t, 100.0/7, last["rollingMean7d"], 1e-9)
+	assert.Equal(t, "2021-12-01", response["peak"].(map[string]interface{})["date"])
+
+	teardownTestData(driver)
+}
+
+// Test the summed series of a region in the region analytics endpoint
+func TestRegionHandler_Analytics(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/regions/Americas/analytics?from=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	points := response["points"].([]interface{})
+	assert.Len(t, points, 1)
+	point := points[0].(map[string]interface{})
+	assert.Equal(t, float64(120), point["value"])
+	assert.InDelta(t, 120.0/7, point["rollingMean7d"], 1e-9)
+	// The previous window starts before the first report, so it is incomplete
+	assert.Nil(t, point["percentChange"])
+
+	teardownTestData(driver)
+}
+
+// Test an invalid window in the analytics endpoint
+func TestCountryHandler_AnalyticsInvalidWindows(t *testing.T) {
+	req := httptest.NewRequest("GET", "/countries/US/analytics?windows=7,week", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'windows' parameter\n", w.Body.String())
+}
+
+// Test the waves detected in the new cases of a country
+func TestCountryHandler_Waves(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/US/waves", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	waves := response["waves"].([]interface{})
+	assert.Len(t, waves, 1)
+	wave := waves[0].(map[string]interface{})
+	assert.Equal(t, "2021-11-24", wave["startDate"])
+	assert.Equal(t, "2021-12-01", wave["peakDate"])
+	assert.Equal(t, float64(100), wave["peakValue"])
+
+	teardownTestData(driver)
+}
+
+// Test an invalid share in the waves endpoint
+func TestCountryHandler_WavesInvalidShare(t *testing.T) {
+	req := httptest.NewRequest("GET", "/countries/US/waves?minPeakShare=2", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'minPeakShare' parameter\n", w.Body.String())
+}
+
+// Test the comparison of several countries on a date
+func TestCompareHandler_Date(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/compare?countries=US,CAN,840,XYZ&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := CompareHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	// Codes of the same country are merged and the requested order is kept
+	rows := response["countries"].([]interface{})
+	assert.Len(t, rows, 3)
+	usa := rows[0].(map[string]interface{})
+	assert.Equal(t, "USA", usa["country"])
+	assert.Equal(t, float64(1000), usa["cumulativeCases"])
+	assert.Equal(t, 0.15, usa["personsVaccinated1PlusDosePer100"])
+	assert.Equal(t, float64(300), rows[1].(map[string]interface{})["cumulativeCases"])
+	unknown := rows[2].(map[string]interface{})
+	assert.Equal(t, "XYZ", unknown["country"])
+	assert.Nil(t, unknown["cumulativeCases"])
+
+	teardownTestData(driver)
+}
+
+// Test the comparison of several countries aligned by report date
+func TestCompareHandler_Range(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/compare?countries=USA,CAN&from=2021-11-01&to=2021-12-31", nil)
+	w := httptest.NewRecorder()
+
+	handler := CompareHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	rows := response["rows"].([]interface{})
+	assert.Len(t, rows, 2)
+	first := rows[0].(map[string]interface{})
+	assert.Equal(t, "2021-11-24", first["date"])
+	values := first["countries"].(map[string]interface{})
+	assert.Equal(t, float64(900), values["USA"].(map[string]interface{})["cumulativeCases"])
+	assert.Equal(t, 0.14, values["USA"].(map[string]interface{})["personsVaccinated1PlusDosePer100"])
+	assert.Nil(t, values["CAN"])
+	values = rows[1].(map[string]interface{})["countries"].(map[string]interface{})
+	assert.Equal(t, float64(300), values["CAN"].(map[string]interface{})["cumulativeCases"])
+
+	teardownTestData(driver)
+}
+
+// Test that a comparison rejects date combined with a range
+func TestCompareHandler_DateWithRange(t *testing.T) {
+	req := httptest.NewRequest("GET", "/compare?countries=US,BR&date=2021-12-01&to=2021-12-31", nil)
+	w := httptest.NewRecorder()
+
+	handler := CompareHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "'date' must not be combined with 'from' or 'to'\n", w.Body.String())
+}
+
+// Test a comparison without countries
+func TestCompareHandler_MissingCountries(t *testing.T) {
+	req := httptest.NewRequest("GET", "/compare?date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := CompareHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Missing 'countries' parameter\n", w.Body.String())
+}
+
+// Test the paginated country catalog filtered by region
+func TestCountriesHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries?region=Americas&limit=1&offset=1", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountriesHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, float64(2), response["total"])
+	page := response["countries"].([]interface{})
+	assert.Len(t, page, 1)
+	assert.Equal(t, "USA", page[0].(map[string]interface{})["code"])
+	assert.Equal(t, "Americas", page[0].(map[string]interface{})["region"])
+
+	teardownTestData(driver)
+}
+
+// Test the fuzzy name search of the country catalog
+func TestCountriesHandler_Search(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries?q=untied%20states", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountriesHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	page := response["countries"].([]interface{})
+	assert.Len(t, page, 1)
+	assert.Equal(t, "United States", page[0].(map[string]interface{})["name"])
+
+	teardownTestData(driver)
+}
+
+// Test the details of a country
+func TestCountryHandler_Detail(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/countries/us", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "USA", response["code"])
+	assert.Equal(t, "US", response["iso2"])
+	assert.Equal(t, "Americas", response["region"])
+	assert.Equal(t, "2021-11-24", response["firstReport"])
+	assert.Equal(t, "2021-12-01", response["lastReport"])
+	assert.Equal(t, float64(2), response["reports"])
+	vaccines := response["vaccines"].([]interface{})
+	assert.Len(t, vaccines, 1)
+	assert.Equal(t, "Pfizer", vaccines[0].(map[string]interface{})["vaccine"])
+	assert.Equal(t, float64(1000), response["latestCovid"].(map[string]interface{})["cumulativeCases"])
+	assert.Equal(t, "2021-12-01", response["latestVaccination"].(map[string]interface{})["dateUpdated"])
+
+	teardownTestData(driver)
+}
+
+// Test an unknown country in the country details
+func TestCountryHandler_DetailNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/countries/XYZ", nil)
+	w := httptest.NewRecorder()
+
+	handler := CountryHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	assert.Equal(t, "No data found\n", w.Body.String())
+}
+
+// Test the vaccine catalog
+func TestVaccinesHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/vaccines", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccinesHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response []map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	var pfizer map[string]interface{}
+	for _, vaccine := range response {
+		if vaccine["product"] == "Pfizer" {
+			pfizer = vaccine
+		}
+	}
+	assert.NotNil(t, pfizer)
+	assert.Equal(t, "Pfizer BioNTech", pfizer["company"])
+	assert.Equal(t, []interface{}{"2021-01-01"}, pfizer["authorizationDates"])
+	assert.Equal(t, float64(1), pfizer["countries"])
+	assert.Equal(t, "2021-01-01", pfizer["firstStartDate"])
+	assert.Equal(t, "2021-01-01", pfizer["lastStartDate"])
+	regions := pfizer["regions"].([]interface{})
+	assert.Len(t, regions, 1)
+	assert.Equal(t, "Americas", regions[0].(map[string]interface{})["region"])
+
+	teardownTestData(driver)
+}
+
+// Test the details of a vaccine
+func TestVaccineHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/vaccines/Sinovac", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccineHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Sinovac", response["product"])
+	assert.Equal(t, []interface{}{}, response["authorizationDates"])
+	assert.Equal(t, "2021-03-01", response["firstStartDate"])
+	countries := response["countries"].([]interface{})
+	assert.Len(t, countries, 1)
+	assert.Equal(t, "CAN", countries[0].(map[string]interface{})["code"])
+	assert.Equal(t, "2021-10-31", countries[0].(map[string]interface{})["endDate"])
+	regions := response["regions"].([]interface{})
+	assert.Len(t, regions, 1)
+	assert.Equal(t, float64(1), regions[0].(map[string]interface{})["countries"])
+	assert.Equal(t, 0.5, regions[0].(map[string]interface{})["share"])
+
+	teardownTestData(driver)
+}
+
+// Test an unknown product in the vaccine details
+func TestVaccineHandler_NotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/vaccines/Unknown%20-%20Vaccine", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccineHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	assert.Equal(t, "No data found\n", w.Body.String())
+}
+
+// Test the ranking of manufacturers by adopting countries
+func TestManufacturersHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/manufacturers?region=Americas", nil)
+	w := httptest.NewRecorder()
+
+	handler := ManufacturersHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	manufacturers := response["manufacturers"].([]interface{})
+	assert.Len(t, manufacturers, 2)
+	first := manufacturers[0].(map[string]interface{})
+	assert.Equal(t, "Pfizer BioNTech", first["manufacturer"])
+	assert.Equal(t, float64(1), first["countries"])
+	assert.Equal(t, []interface{}{"Pfizer"}, first["products"])
+	assert.Equal(t, float64(1), first["rank"])
+	assert.Equal(t, true, first["tied"])
+	assert.Equal(t, float64(1), manufacturers[1].(map[string]interface{})["rank"])
+
+	teardownTestData(driver)
+}
+
+// Test the manufacturer ranking considering only the vaccines in use on a date
+func TestManufacturersHandler_Date(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/manufacturers?region=Americas&date=2021-12-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := ManufacturersHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	manufacturers := response["manufacturers"].([]interface{})
+	assert.Len(t, manufacturers, 1)
+	assert.Equal(t, "Pfizer BioNTech", manufacturers[0].(map[string]interface{})["manufacturer"])
+	assert.Equal(t, false, manufacturers[0].(map[string]interface{})["tied"])
+
+	teardownTestData(driver)
+}
+
+// Test the products and adoption timeline of a manufacturer
+func TestManufacturerHandler(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/manufacturers/Pfizer%20BioNTech", nil)
+	w := httptest.NewRecorder()
+
+	handler := ManufacturerHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Pfizer BioNTech", response["manufacturer"])
+	assert.Equal(t, float64(1), response["countries"])
+	products := response["products"].([]interface{})
+	assert.Len(t, products, 1)
+	assert.Equal(t, "Pfizer", products[0].(map[string]interface{})["product"])
+	assert.Equal(t, "2021-01-01", products[0].(map[string]interface{})["firstStartDate"])
+	timeline := response["timeline"].([]interface{})
+	assert.Len(t, timeline, 1)
+	month := timeline[0].(map[string]interface{})
+	assert.Equal(t, "2021-01", month["month"])
+	assert.Equal(t, []interface{}{"USA"}, month["countries"])
+	assert.Equal(t, float64(1), month["cumulativeCountries"])
+
+	teardownTestData(driver)
+}
+
+// Test an unknown manufacturer
+func TestManufacturerHandler_NotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/manufacturers/Unknown", nil)
+	w := httptest.NewRecorder()
+
+	handler := ManufacturerHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	assert.Equal(t, "No data found\n", w.Body.String())
+}
+
+// Test the vaccine adoption timeline of a region
+func TestRegionHandler_VaccineTimeline(t *testing.T) {
+	setupTestData(driver)
+
+	req := httptest.NewRequest("GET", "/regions/Americas/vaccine-timeline", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+
+	var response map[string]interface{}
+	err := json.NewDecoder(w.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Americas", response["region"])
+	assert.Equal(t, float64(2), response["countries"])
+	products := response["products"].([]interface{})
+	assert.Len(t, products, 2)
+	sinovac := products[1].(map[string]interface{})
+	assert.Equal(t, "Sinovac", sinovac["product"])
+	adoptions := sinovac["adoptions"].([]interface{})
+	assert.Len(t, adoptions, 1)
+	assert.Equal(t, "CAN", adoptions[0].(map[string]interface{})["country"])
+	assert.Equal(t, "2021-03-01", adoptions[0].(map[string]interface{})["startDate"])
+	assert.Len(t, sinovac["timeline"].([]interface{}), 1)
+
+	// Pfizer started in the USA in January and Sinovac in Canada in March,
+	// so February has no new adoptions
+	timeline := response["timeline"].([]interface{})
+	assert.Len(t, timeline, 3)
+	assert.Equal(t, "2021-02", timeline[1].(map[string]interface{})["month"])
+	assert.Equal(t, float64(0), timeline[1].(map[string]interface{})["newCountries"])
+	assert.Equal(t, float64(1), timeline[1].(map[string]interface{})["cumulativeCountries"])
+	assert.Equal(t, float64(2), timeline[2].(map[string]interface{})["cumulativeCountries"])
+
+	teardownTestData(driver)
+}
+
+// Test the vaccine adoption timeline of an unknown region
+func TestRegionHandler_VaccineTimelineNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/regions/Unknown/vaccine-timeline", nil)
+	w := httptest.NewRecorder()
+
+	handler := RegionHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+	assert.Equal(t, "No data found\n", w.Body.String())
+}
+
+// Test an invalid date in the vaccines used in a country
+func TestVaccinesUsedHandler_InvalidDate(t *testing.T) {
+	req := httptest.NewRequest("GET", "/vaccines-used?country=USA&date=2021-13-01", nil)
+	w := httptest.NewRecorder()
+
+	handler := VaccinesUsedHandler(driver)
+	handler(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+	assert.Equal(t, "Invalid 'date' parameter\n", w.Body.String())
+}
+
 // Function to populate the database with test data
 func setupTestData(driver neo4j.DriverWithContext) {
 	ctx := context.Background()
@@ -239,21 +1345,53 @@ func setupTestData(driver neo4j.DriverWithContext) {
 	defer session.Close(ctx)
 
 	_, err := session.Run(ctx,
-		`MERGE (c:Country {code: "US", name: "United States"})
+		`MERGE (c:Country {code: "USA", iso2: "US", iso3: "USA", name: "United States"})
+         SET c.population = 331000000
          MERGE (d:Date {date: date("2021-12-01")})
          MERGE (dStart:Date {date: date("2021-01-01")})
-         MERGE (cs:CovidStats {date: date("2021-12-01"), countryCode: "US"})
-         SET cs.cumulativeCases = 1000, cs.cumulativeDeaths = 50
+         MERGE (cs:CovidStats {date: date("2021-12-01"), countryCode: "USA"})
+         SET cs.cumulativeCases = 1000, cs.cumulativeDeaths = 50, cs.newCases = 100, cs.newDeaths = 5
          MERGE (c)-[:REPORTED_ON]->(cs)
          MERGE (cs)-[:ON_DATE]->(d)
-         MERGE (vs:VaccinationStats {totalVaccinations: 500, personsVaccinated1PlusDose: 500, date: date("2021-12-01"), countryCode: "US"})
+         MERGE (dPrevious:Date {date: date("2021-11-24")})
+         MERGE (csPrevious:CovidStats {date: date("2021-11-24"), countryCode: "USA"})
+         SET csPrevious.cumulativeCases = 900, csPrevious.cumulativeDeaths = 45, csPrevious.newCases = 80, csPrevious.newDeaths = 4
+         MERGE (c)-[:REPORTED_ON]->(csPrevious)
+         MERGE (csPrevious)-[:ON_DATE]->(dPrevious)
+         MERGE (vs:VaccinationStats {totalVaccinations: 500, personsVaccinated1PlusDose: 500, dateUpdated: date("2021-12-01"), countryCode: "USA"})
+         SET vs.personsVaccinated1PlusDosePer100 = 0.15
          MERGE (c)-[:VACCINATED_ON]->(vs)
          MERGE (vs)-[:ON_DATE]->(d)
+         MERGE (vsPrevious:VaccinationStats {countryCode: "USA", dateUpdated: date("2021-11-24")})
+         SET vsPrevious.totalVaccinations = 450, vsPrevious.personsVaccinated1PlusDose = 450, vsPrevious.personsVaccinated1PlusDosePer100 = 0.14
+         MERGE (c)-[:VACCINATED_ON]->(vsPrevious)
+         MERGE (vsPrevious)-[:ON_DATE]->(dPrevious)
          MERGE (v:Vaccine {product: "Pfizer"})
-         MERGE (c)-[:USES]->(v)
+         SET v.company = "Pfizer BioNTech", v.vaccine = "Comirnaty"
+         MERGE (v)-[:AUTHORIZATION_ON]->(dStart)
+         MERGE (pfizerInc:Manufacturer {name: "Pfizer BioNTech"})
+         MERGE (pfizerInc)-[:PRODUCES]->(v)
+         MERGE (c)-[:USES {startDate: date("2021-01-01"), dataSource: "REPORTING"}]->(v)
          MERGE (v)-[:STARTED_ON]->(dStart)
          MERGE (r:Region {name: "Americas"})
-         MERGE (c)-[:BELONGS]->(r)`,
+         MERGE (c)-[:BELONGS]->(r)
+         MERGE (ca:Country {code: "CAN", iso2: "CA", iso3: "CAN", name: "Canada"})
+         SET ca.population = 38000000
+         MERGE (csCanada:CovidStats {date: date("2021-12-01"), countryCode: "CAN"})
+         SET csCanada.cumulativeCases = 300, csCanada.cumulativeDeaths = 60, csCanada.newCases = 20, csCanada.newDeaths = 2
+         MERGE (ca)-[:REPORTED_ON]->(csCanada)
+         MERGE (csCanada)-[:ON_DATE]->(d)
+         MERGE (ca)-[:BELONGS]->(r)
+         MERGE (sinovac:Vaccine {product: "Sinovac"})
+         SET sinovac.company = "Sinovac"
+         MERGE (sinovacInc:Manufacturer {name: "Sinovac"})
+         MERGE (sinovacInc)-[:PRODUCES]->(sinovac)
+         MERGE (ca)-[:USES {startDate: date("2021-03-01"), endDate: date("2021-10-31"), dataSource: "REPORTING"}]->(sinovac)
+         MERGE (run:ImportRun {id: "test-run"})
+         SET run.dataset = "global-data", run.source = "data/WHO-COVID-19-global-data.csv", run.status = "completed",
+             run.startedAt = datetime("2021-12-02T10:00:00Z"), run.finishedAt = datetime("2021-12-02T10:05:00Z"),
+             run.watermark = "2021-12-01", run.rowsRead = 1, run.rowsWritten = 1, run.rowsRejected = 0, run.rowsSkipped = 0
+         MERGE (run)-[:IMPORTED]->(cs)`,
 		nil)
 
 	if err != nil {
@@ -268,28 +1406,38 @@ func teardownTestData(driver neo4j.DriverWithContext) {
 	defer session.Close(ctx)
 
 	_, err := session.Run(ctx,
-		`MATCH (c:Country {code: "US"})
+		`MATCH (c:Country)
+         WHERE c.code IN ["USA", "CAN"]
          DETACH DELETE c
          WITH c
          MATCH (cs:CovidStats)
-         WHERE cs.date = date("2021-12-01")
+         WHERE cs.date IN [date("2021-12-01"), date("2021-11-24")]
          DETACH DELETE cs
          WITH cs
          MATCH (vs:VaccinationStats)
-         WHERE vs.date = date("2021-12-01")
+         WHERE vs.dateUpdated IN [date("2021-12-01"), date("2021-11-24")]
          DETACH DELETE vs
          WITH vs
-         MATCH (v:Vaccine {product: "Pfizer"})
+         MATCH (v:Vaccine)
+         WHERE v.product IN ["Pfizer", "Sinovac"]
          DETACH DELETE v
          WITH v
-         MATCH (d:Date {date: date("2021-12-01")})
+         MATCH (d:Date)
+         WHERE d.date IN [date("2021-12-01"), date("2021-11-24")]
          DETACH DELETE d
          WITH d
          MATCH (dStart:Date {date: date("2021-01-01")})
          DETACH DELETE dStart
          WITH dStart
          MATCH (r:Region {name: "Americas"})
-         DETACH DELETE r`,
+         DETACH DELETE r
+         WITH r
+         MATCH (run:ImportRun {id: "test-run"})
+         DETACH DELETE run
+         WITH run
+         MATCH (m:Manufacturer)
+         WHERE m.name IN ["Pfizer BioNTech", "Sinovac"]
+         DETACH DELETE m`,
 		nil)
 
 	if err != nil {
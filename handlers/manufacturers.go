@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func ManufacturersHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		region := r.URL.Query().Get("region")
+		date := r.URL.Query().Get("date")
+
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// Um país adota o fabricante quando usa qualquer uma das suas vacinas
+		result, err := session.Run(ctx,
+			`MATCH (m:Manufacturer)-[:PRODUCES]->(v:Vaccine)<-[u:USES]-(c:Country)
+             WHERE ($region = "" OR (c)-[:BELONGS]->(:Region {name: $region})) AND `+usesActiveOnDate+`
+             WITH m, count(DISTINCT c) AS countries, collect(DISTINCT v.product) AS products
+             RETURN m.name AS manufacturer, countries, products
+             ORDER BY countries DESC, manufacturer`,
+			map[string]interface{}{
+				"region": region,
+				"date":   date,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var manufacturers []map[string]interface{}
+		for result.Next(ctx) {
+			manufacturers = append(manufacturers, result.Record().AsMap())
+		}
+		if len(manufacturers) == 0 {
+			http.Error(w, "No data found", http.StatusNotFound)
+			return
+		}
+
+		// Fabricantes com a mesma quantidade de países recebem o mesmo rank e são
+		// marcados como empatados
+		rankTies(manufacturers, "countries")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"region":        region,
+			"date":          date,
+			"manufacturers": manufacturers,
+		})
+	}
+}
+
+// ManufacturerHandler atende a rota /manufacturers/{name}. O nome pode conter
+// espaços e barras, então deve vir codificado na URL
+func ManufacturerHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := pathSegments(r.URL.EscapedPath(), "/manufacturers/")
+		if len(segments) != 1 {
+			http.NotFound(w, r)
+			return
+		}
+		name, err := url.PathUnescape(segments[0])
+		if err != nil {
+			http.Error(w, "Invalid 'name' parameter", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// A linha do tempo considera, para cada país, a primeira data de início
+		// de uso entre todas as vacinas do fabricante
+		result, err := session.Run(ctx,
+			`MATCH (m:Manufacturer {name: $name})
+             CALL {
+                 WITH m
+                 OPTIONAL MATCH (m)-[:PRODUCES]->(v:Vaccine)
+                 OPTIONAL MATCH (c:Country)-[u:USES]->(v)
+                 WITH v, count(DISTINCT c) AS countries, min(u.startDate) AS firstStart
+                 ORDER BY v.product
+                 RETURN collect(CASE WHEN v IS NOT NULL THEN {product: v.product, vaccine: v.vaccine, countries: countries,
+                                     firstStartDate: toString(firstStart)} END) AS products
+             }
+             CALL {
+                 WITH m
+                 OPTIONAL MATCH (m)-[:PRODUCES]->(:Vaccine)<-[u:USES]-(c:Country)
+                 WITH c, min(u.startDate) AS startDate
+                 RETURN count(c) AS countries,
+                        collect(CASE WHEN c IS NOT NULL THEN {country: c.code, startDate: toString(startDate)} END) AS adoptions
+             }
+             RETURN m.name AS manufacturer, countries, products, adoptions`,
+			map[string]interface{}{
+				"name": name,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if !result.Next(ctx) {
+			http.Error(w, "No data found", http.StatusNotFound)
+			return
+		}
+		response := result.Record().AsMap()
+		response["timeline"] = adoptionTimeline(adoptionsFromRecord(response["adoptions"]))
+		delete(response, "adoptions")
+
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import "fmt"
+
+// Expressão Cypher de value por 100 mil habitantes, nula quando o país não
+// tem população carregada
+func per100k(value, population string) string {
+	return fmt.Sprintf("CASE WHEN %[2]s > 0 THEN %[1]s * 100000.0 / %[2]s END", value, population)
+}
+
+// Expressão Cypher da letalidade: percentual de mortes entre os casos
+// acumulados, nula quando não há casos
+func caseFatalityRate(deaths, cases string) string {
+	return fmt.Sprintf("CASE WHEN %[2]s > 0 THEN %[1]s * 100.0 / %[2]s END", deaths, cases)
+}
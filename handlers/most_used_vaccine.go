@@ -12,23 +12,29 @@ import (
 func MostUsedVaccineHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		region := r.URL.Query().Get("region")
+		date := r.URL.Query().Get("date")
 
 		if region == "" {
 			http.Error(w, "Missing 'region' parameter", http.StatusBadRequest)
 			return
 		}
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
 
 		ctx := context.Background()
 		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 		defer session.Close(ctx)
 
 		result, err := session.Run(ctx,
-			`MATCH (r:Region {name: $region})<-[:BELONGS]-(c:Country)-[:USES]->(v:Vaccine)
-             RETURN v.product AS vaccine, COUNT(c) AS usage
-             ORDER BY usage DESC
-             LIMIT 1`,
+			`MATCH (r:Region {name: $region})<-[:BELONGS]-(c:Country)-[u:USES]->(v:Vaccine)
+             WHERE `+usesActiveOnDate+`
+             RETURN v.product AS vaccine, COUNT(DISTINCT c) AS usage
+             ORDER BY usage DESC, vaccine`,
 			map[string]interface{}{
 				"region": region,
+				"date":   date,
 			})
 
 		if err != nil {
@@ -36,13 +42,27 @@ func MostUsedVaccineHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 			return
 		}
 
-		if result.Next(ctx) {
+		// Todas as vacinas com o mesmo uso da primeira estão empatadas; vaccine
+		// continua sendo a primeira em ordem alfabética
+		var vaccine, usage interface{}
+		var tied []interface{}
+		for result.Next(ctx) {
 			record := result.Record()
-			vaccine, _ := record.Get("vaccine")
-			usage, _ := record.Get("usage")
+			product, _ := record.Get("vaccine")
+			count, _ := record.Get("usage")
+			if len(tied) == 0 {
+				vaccine, usage = product, count
+			} else if count != usage {
+				break
+			}
+			tied = append(tied, product)
+		}
+		if len(tied) > 0 {
 			response := map[string]interface{}{
-				"vaccine": vaccine,
-				"usage":   usage,
+				"vaccine":  vaccine,
+				"usage":    usage,
+				"tie":      len(tied) > 1,
+				"vaccines": tied,
 			}
 			json.NewEncoder(w).Encode(response)
 		} else {
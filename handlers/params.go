@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Lê um parâmetro inteiro opcional com valor mínimo min; em caso de erro a
+// resposta já foi escrita e ok é falso
+func intParam(w http.ResponseWriter, r *http.Request, name string, def, min int) (n int, ok bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min {
+		http.Error(w, fmt.Sprintf("Invalid '%s' parameter", name), http.StatusBadRequest)
+		return 0, false
+	}
+	return n, true
+}
+
+// Valida um parâmetro opcional de data no formato YYYY-MM-DD
+func validDate(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := time.Parse("2006-01-02", value)
+	return err == nil
+}
+
+// Lê os parâmetros from e to de um intervalo de datas; em caso de erro a
+// resposta já foi escrita e ok é falso
+func dateRange(w http.ResponseWriter, r *http.Request) (from, to string, ok bool) {
+	from = r.URL.Query().Get("from")
+	to = r.URL.Query().Get("to")
+
+	if !validDate(from) {
+		http.Error(w, "Invalid 'from' parameter", http.StatusBadRequest)
+		return "", "", false
+	}
+	if !validDate(to) {
+		http.Error(w, "Invalid 'to' parameter", http.StatusBadRequest)
+		return "", "", false
+	}
+	if from != "" && to != "" && from > to {
+		http.Error(w, "'from' must not be after 'to'", http.StatusBadRequest)
+		return "", "", false
+	}
+	return from, to, true
+}
+
+// Modos do parâmetro match: a data exata, o relatório mais recente até a
+// data, o primeiro a partir dela ou o mais próximo em qualquer direção
+var dateMatches = map[string]bool{"exact": true, "previous": true, "next": true, "nearest": true}
+
+// Lê os parâmetros date (obrigatório) e match (padrão exact); em caso de erro
+// a resposta já foi escrita e ok é falso
+func dateMatch(w http.ResponseWriter, r *http.Request) (date, match string, ok bool) {
+	date = r.URL.Query().Get("date")
+	match = r.URL.Query().Get("match")
+	if match == "" {
+		match = "exact"
+	}
+
+	if !validDate(date) {
+		http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+		return "", "", false
+	}
+	if !dateMatches[match] {
+		http.Error(w, "Invalid 'match' parameter", http.StatusBadRequest)
+		return "", "", false
+	}
+	return date, match, true
+}
+
+// Filtro e ordenação que deixam na primeira linha o relatório escolhido pelo
+// modo $match para a data $date; dateExpr é a data do relatório na consulta
+func dateMatchClauses(dateExpr string) (where, orderBy string) {
+	where = fmt.Sprintf(`WHERE CASE $match
+                   WHEN "exact" THEN %[1]s = date($date)
+                   WHEN "previous" THEN %[1]s <= date($date)
+                   WHEN "next" THEN %[1]s >= date($date)
+                   ELSE true
+               END`, dateExpr)
+	orderBy = fmt.Sprintf(`ORDER BY abs(duration.inDays(date($date), %[1]s).days), %[1]s`, dateExpr)
+	return where, orderBy
+}
+
+// Divide o caminho de uma rota como /countries/{code}/covid nos segmentos
+// após o prefixo
+func pathSegments(path, prefix string) []string {
+	path = strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// Numera as linhas já ordenadas pelo valor de key: linhas com o mesmo valor
+// recebem o mesmo rank e são marcadas como empatadas (tied)
+func rankTies(rows []map[string]interface{}, key string) {
+	for i, row := range rows {
+		row["rank"] = i + 1
+		if i > 0 && row[key] == rows[i-1][key] {
+			row["rank"] = rows[i-1]["rank"]
+		}
+		row["tied"] = (i > 0 && row[key] == rows[i-1][key]) ||
+			(i+1 < len(rows) && row[key] == rows[i+1][key])
+	}
+}
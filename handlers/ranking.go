@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Métricas aceitas pelo ranking e a consulta que produz, para cada país, o
+// valor da métrica na data ($date) como c e value
+var rankingMetrics = map[string]string{
+	"cumulativeCases":                  covidMetric("cs.cumulativeCases"),
+	"cumulativeDeaths":                 covidMetric("cs.cumulativeDeaths"),
+	"newCases":                         covidMetric("cs.newCases"),
+	"newDeaths":                        covidMetric("cs.newDeaths"),
+	"cumulativeCasesPer100k":           covidMetric(per100k("cs.cumulativeCases", "c.population")),
+	"cumulativeDeathsPer100k":          covidMetric(per100k("cs.cumulativeDeaths", "c.population")),
+	"newCasesPer100k":                  covidMetric(per100k("cs.newCases", "c.population")),
+	"newDeathsPer100k":                 covidMetric(per100k("cs.newDeaths", "c.population")),
+	"caseFatalityRate":                 covidMetric(caseFatalityRate("cs.cumulativeDeaths", "cs.cumulativeCases")),
+	"totalVaccinations":                vaccinationMetric("totalVaccinations"),
+	"totalVaccinationsPer100":          vaccinationMetric("totalVaccinationsPer100"),
+	"personsVaccinated1PlusDose":       vaccinationMetric("personsVaccinated1PlusDose"),
+	"personsVaccinated1PlusDosePer100": vaccinationMetric("personsVaccinated1PlusDosePer100"),
+	"personsLastDose":                  vaccinationMetric("personsLastDose"),
+	"personsLastDosePer100":            vaccinationMetric("personsLastDosePer100"),
+	"personsBoosterAddDose":            vaccinationMetric("personsBoosterAddDose"),
+	"personsBoosterAddDosePer100":      vaccinationMetric("personsBoosterAddDosePer100"),
+}
+
+// Casos e mortes do relatório da data; expression é calculada sobre c e cs
+func covidMetric(expression string) string {
+	return fmt.Sprintf(`MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(:Date {date: date($date)})
+             WITH c, %s AS value`, expression)
+}
+
+// Como cada país atualiza a vacinação em uma data diferente, usa o relatório
+// mais recente até a data
+func vaccinationMetric(property string) string {
+	return fmt.Sprintf(`MATCH (c:Country)-[:VACCINATED_ON]->(vs:VaccinationStats)
+             WHERE vs.dateUpdated <= date($date)
+             WITH c, vs ORDER BY vs.dateUpdated DESC
+             WITH c, head(collect(vs)).%s AS value`, property)
+}
+
+func RankingHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		metric := r.URL.Query().Get("metric")
+		date := r.URL.Query().Get("date")
+		region := r.URL.Query().Get("region")
+
+		if metric == "" || date == "" {
+			http.Error(w, "Missing 'metric' or 'date' parameter", http.StatusBadRequest)
+			return
+		}
+		metricQuery, found := rankingMetrics[metric]
+		if !found {
+			http.Error(w, "Invalid 'metric' parameter", http.StatusBadRequest)
+			return
+		}
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
+
+		// A ordem e o operador do rank entram no texto da consulta, então só
+		// aceitam os valores fixos abaixo
+		order := r.URL.Query().Get("order")
+		var direction, better string
+		switch order {
+		case "", "desc":
+			order, direction, better = "desc", "DESC", ">"
+		case "asc":
+			direction, better = "ASC", "<"
+		default:
+			http.Error(w, "Invalid 'order' parameter", http.StatusBadRequest)
+			return
+		}
+
+		limit, ok := intParam(w, r, "limit", 10, 1)
+		if !ok {
+			return
+		}
+		offset, ok := intParam(w, r, "offset", 0, 0)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// Países empatados recebem o mesmo rank: 1 + quantidade de países com
+		// valor melhor
+		result, err := session.Run(ctx,
+			metricQuery+`
+             WITH c, value
+             WHERE value IS NOT NULL AND ($region = "" OR (c)-[:BELONGS]->(:Region {name: $region}))
+             WITH collect({country: c.code, name: c.name, region: head([(c)-[:BELONGS]->(r:Region) | r.name]), value: value}) AS rows
+             UNWIND rows AS row
+             RETURN size([other IN rows WHERE other.value `+better+` row.value]) + 1 AS rank,
+                    row.country AS country, row.name AS name, row.region AS region, row.value AS value,
+                    size(rows) AS total
+             ORDER BY row.value `+direction+`, row.country
+             SKIP $offset
+             LIMIT $limit`,
+			map[string]interface{}{
+				"date":   date,
+				"region": region,
+				"offset": offset,
+				"limit":  limit,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var total interface{}
+		var ranking []map[string]interface{}
+		for result.Next(ctx) {
+			record := result.Record()
+			total, _ = record.Get("total")
+			rank, _ := record.Get("rank")
+			country, _ := record.Get("country")
+			name, _ := record.Get("name")
+			countryRegion, _ := record.Get("region")
+			value, _ := record.Get("value")
+			ranking = append(ranking, map[string]interface{}{
+				"rank":    rank,
+				"country": country,
+				"name":    name,
+				"region":  countryRegion,
+				"value":   value,
+			})
+		}
+		if len(ranking) > 0 {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"metric":  metric,
+				"date":    date,
+				"order":   order,
+				"total":   total,
+				"ranking": ranking,
+			})
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
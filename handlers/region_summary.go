@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func regionSummaryHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, region string) {
+	return func(w http.ResponseWriter, r *http.Request, region string) {
+		date := r.URL.Query().Get("date")
+
+		if date == "" {
+			http.Error(w, "Missing 'date' parameter", http.StatusBadRequest)
+			return
+		}
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
+		top, ok := intParam(w, r, "top", 5, 0)
+		if !ok {
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// Casos e mortes somam os relatórios da data; os valores por 100 mil
+		// habitantes consideram apenas os países com população carregada. A
+		// vacinação usa o relatório mais recente de cada país até a data e a
+		// cobertura é ponderada pela população do país ou, sem ela, pela
+		// população estimada a partir dos valores por 100 habitantes.
+		result, err := session.Run(ctx,
+			`MATCH (r:Region {name: $region})<-[:BELONGS]-(c:Country)
+             WITH collect(c) AS regionCountries, sum(c.population) AS population
+             WHERE size(regionCountries) > 0
+             CALL {
+                 WITH regionCountries
+                 UNWIND regionCountries AS c
+                 MATCH (c)-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(:Date {date: date($date)})
+                 WITH c, cs ORDER BY cs.newCases DESC, c.code
+                 RETURN count(cs) AS reportingCountries,
+                        sum(cs.cumulativeCases) AS cumulativeCases, sum(cs.cumulativeDeaths) AS cumulativeDeaths,
+                        sum(cs.newCases) AS newCases, sum(cs.newDeaths) AS newDeaths,
+                        sum(CASE WHEN c.population > 0 THEN c.population END) AS reportingPopulation,
+                        sum(CASE WHEN c.population > 0 THEN cs.cumulativeCases END) AS populationCases,
+                        sum(CASE WHEN c.population > 0 THEN cs.cumulativeDeaths END) AS populationDeaths,
+                        collect({country: c.code, name: c.name, newCases: cs.newCases, newDeaths: cs.newDeaths,
+                                 cumulativeCases: cs.cumulativeCases}) AS reports
+             }
+             CALL {
+                 WITH regionCountries
+                 UNWIND regionCountries AS c
+                 MATCH (c)-[:VACCINATED_ON]->(vs:VaccinationStats)
+                 WHERE vs.dateUpdated <= date($date)
+                 WITH c, vs ORDER BY vs.dateUpdated DESC
+                 WITH c, head(collect(vs)) AS vs
+                 WITH c, vs,
+                      CASE WHEN vs.personsVaccinated1PlusDose IS NULL THEN null
+                           WHEN c.population > 0 THEN c.population
+                           WHEN vs.personsVaccinated1PlusDosePer100 > 0
+                           THEN vs.personsVaccinated1PlusDose * 100.0 / vs.personsVaccinated1PlusDosePer100
+                      END AS population1PlusDose,
+                      CASE WHEN vs.personsLastDose IS NULL THEN null
+                           WHEN c.population > 0 THEN c.population
+                           WHEN vs.personsLastDosePer100 > 0
+                           THEN vs.personsLastDose * 100.0 / vs.personsLastDosePer100
+                      END AS populationLastDose
+                 RETURN count(c) AS vaccinationCountries,
+                        sum(CASE WHEN population1PlusDose IS NOT NULL THEN vs.personsVaccinated1PlusDose END) AS vaccinated1PlusDose,
+                        sum(population1PlusDose) AS population1PlusDose,
+                        sum(CASE WHEN populationLastDose IS NOT NULL THEN vs.personsLastDose END) AS vaccinatedLastDose,
+                        sum(populationLastDose) AS populationLastDose
+             }
+             RETURN size(regionCountries) AS countries, CASE WHEN population > 0 THEN population END AS population,
+                    reportingCountries,
+                    cumulativeCases, cumulativeDeaths, newCases, newDeaths,
+                    `+per100k("populationCases", "reportingPopulation")+` AS cumulativeCasesPer100k,
+                    `+per100k("populationDeaths", "reportingPopulation")+` AS cumulativeDeathsPer100k,
+                    `+caseFatalityRate("cumulativeDeaths", "cumulativeCases")+` AS caseFatalityRate,
+                    [report IN reports[..$top] | report {.*, share: CASE WHEN newCases > 0 THEN toFloat(report.newCases) / newCases END}] AS topContributors,
+                    vaccinationCountries,
+                    CASE WHEN population1PlusDose > 0 THEN vaccinated1PlusDose * 100 / population1PlusDose END AS personsVaccinated1PlusDosePer100,
+                    CASE WHEN populationLastDose > 0 THEN vaccinatedLastDose * 100 / populationLastDose END AS personsLastDosePer100`,
+			map[string]interface{}{
+				"region": region,
+				"date":   date,
+				"top":    top,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if result.Next(ctx) {
+			response := result.Record().AsMap()
+			response["region"] = region
+			response["date"] = date
+			json.NewEncoder(w).Encode(response)
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func regionVaccineTimelineHandler(driver neo4j.DriverWithContext) func(w http.ResponseWriter, r *http.Request, region string) {
+	return func(w http.ResponseWriter, r *http.Request, region string) {
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// A relação STARTED_ON liga a vacina apenas à data, sem o país; a data de
+		// início de cada país é a mesma coluna START_DATE, gravada em USES.startDate
+		result, err := session.Run(ctx,
+			`MATCH (r:Region {name: $region})<-[:BELONGS]-(c:Country)
+             WITH r, count(c) AS countries
+             CALL {
+                 WITH r
+                 OPTIONAL MATCH (r)<-[:BELONGS]-(c:Country)-[u:USES]->(v:Vaccine)
+                 WHERE u.startDate IS NOT NULL
+                 WITH v, c, min(u.startDate) AS startDate ORDER BY startDate, c.code
+                 WITH v, collect(CASE WHEN c IS NOT NULL THEN {country: c.code, startDate: toString(startDate)} END) AS adoptions
+                 ORDER BY v.product
+                 RETURN collect(CASE WHEN v IS NOT NULL THEN {product: v.product, company: v.company, adoptions: adoptions} END) AS products
+             }
+             RETURN r.name AS region, countries, products`,
+			map[string]interface{}{
+				"region": region,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if !result.Next(ctx) {
+			http.Error(w, "No data found", http.StatusNotFound)
+			return
+		}
+		response := result.Record().AsMap()
+
+		// A linha do tempo geral considera a primeira vacina iniciada em cada país
+		firstStart := map[string]string{}
+		products := []map[string]interface{}{}
+		productValues, _ := response["products"].([]interface{})
+		for _, value := range productValues {
+			product, _ := value.(map[string]interface{})
+			adoptions := adoptionsFromRecord(product["adoptions"])
+			for _, a := range adoptions {
+				if start, ok := firstStart[a.country]; !ok || a.startDate < start {
+					firstStart[a.country] = a.startDate
+				}
+			}
+			product["timeline"] = adoptionTimeline(adoptions)
+			products = append(products, product)
+		}
+		var adoptions []adoption
+		for country, startDate := range firstStart {
+			adoptions = append(adoptions, adoption{country: country, startDate: startDate})
+		}
+
+		response["products"] = products
+		response["timeline"] = adoptionTimeline(adoptions)
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func RegionsHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx,
+			`MATCH (r:Region)
+             RETURN r.name AS name, COUNT { (r)<-[:BELONGS]-(:Country) } AS countries
+             ORDER BY name`,
+			nil)
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var regions []map[string]interface{}
+		for result.Next(ctx) {
+			regions = append(regions, result.Record().AsMap())
+		}
+		if len(regions) > 0 {
+			json.NewEncoder(w).Encode(regions)
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
+
+// RegionHandler atende as rotas /regions/{name}/..., repassando o nome da
+// região para o handler do recurso
+func RegionHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	summary := regionSummaryHandler(driver)
+	analyticsSeries := regionAnalyticsHandler(driver)
+	vaccineTimeline := regionVaccineTimelineHandler(driver)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := pathSegments(r.URL.Path, "/regions/")
+		if len(segments) != 2 {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch segments[1] {
+		case "summary":
+			summary(w, r, segments[0])
+		case "analytics":
+			analyticsSeries(w, r, segments[0])
+		case "vaccine-timeline":
+			vaccineTimeline(w, r, segments[0])
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
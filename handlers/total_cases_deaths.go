@@ -6,29 +6,43 @@ import (
 	"fmt"
 	"net/http"
 
+	"desafiogolang-neo4j/countries"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 func TotalCasesDeathsHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		country := r.URL.Query().Get("country")
-		date := r.URL.Query().Get("date")
 
-		if country == "" || date == "" {
+		if country == "" || r.URL.Query().Get("date") == "" {
 			http.Error(w, "Missing 'country' or 'date' parameter", http.StatusBadRequest)
 			return
 		}
+		date, match, ok := dateMatch(w, r)
+		if !ok {
+			return
+		}
 
 		ctx := context.Background()
 		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 		defer session.Close(ctx)
 
+		where, orderBy := dateMatchClauses("d.date")
 		result, err := session.Run(ctx,
-			`MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date {date: date($date)})
-             RETURN cs.cumulativeCases AS totalCumulativeCases, cs.cumulativeDeaths AS totalCumulativeDeaths`,
+			`MATCH (c:Country {code: $countryCode})-[:REPORTED_ON]->(cs:CovidStats)-[:ON_DATE]->(d:Date)
+             `+where+`
+             RETURN cs.cumulativeCases AS totalCumulativeCases, cs.cumulativeDeaths AS totalCumulativeDeaths,
+                    toString(d.date) AS date, c.population AS population,
+                    `+per100k("cs.cumulativeCases", "c.population")+` AS cumulativeCasesPer100k,
+                    `+per100k("cs.cumulativeDeaths", "c.population")+` AS cumulativeDeathsPer100k,
+                    `+caseFatalityRate("cs.cumulativeDeaths", "cs.cumulativeCases")+` AS caseFatalityRate
+             `+orderBy+`
+             LIMIT 1`,
 			map[string]interface{}{
-				"countryCode": country,
+				"countryCode": countries.Canonical(country),
 				"date":        date,
+				"match":       match,
 			})
 
 		if err != nil {
@@ -40,9 +54,19 @@ func TotalCasesDeathsHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 			record := result.Record()
 			totalCumulativeCases, _ := record.Get("totalCumulativeCases")
 			totalCumulativeDeaths, _ := record.Get("totalCumulativeDeaths")
+			reportDate, _ := record.Get("date")
+			population, _ := record.Get("population")
+			cumulativeCasesPer100k, _ := record.Get("cumulativeCasesPer100k")
+			cumulativeDeathsPer100k, _ := record.Get("cumulativeDeathsPer100k")
+			fatalityRate, _ := record.Get("caseFatalityRate")
 			response := map[string]interface{}{
-				"totalCumulativeCases":  totalCumulativeCases,
-				"totalCumulativeDeaths": totalCumulativeDeaths,
+				"totalCumulativeCases":    totalCumulativeCases,
+				"totalCumulativeDeaths":   totalCumulativeDeaths,
+				"date":                    reportDate,
+				"population":              population,
+				"cumulativeCasesPer100k":  cumulativeCasesPer100k,
+				"cumulativeDeathsPer100k": cumulativeDeathsPer100k,
+				"caseFatalityRate":        fatalityRate,
 			}
 			json.NewEncoder(w).Encode(response)
 		} else {
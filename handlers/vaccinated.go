@@ -6,29 +6,39 @@ import (
 	"fmt"
 	"net/http"
 
+	"desafiogolang-neo4j/countries"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 func VaccinatedHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		country := r.URL.Query().Get("country")
-		date := r.URL.Query().Get("date")
 
-		if country == "" || date == "" {
+		if country == "" || r.URL.Query().Get("date") == "" {
 			http.Error(w, "Missing 'country' or 'date' parameter", http.StatusBadRequest)
 			return
 		}
+		date, match, ok := dateMatch(w, r)
+		if !ok {
+			return
+		}
 
 		ctx := context.Background()
 		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 		defer session.Close(ctx)
 
+		where, orderBy := dateMatchClauses("vs.dateUpdated")
 		result, err := session.Run(ctx,
-			`MATCH (c:Country {code: $countryCode})-[:VACCINATED_ON]->(vs:VaccinationStats)-[:ON_DATE]->(d:Date {date: date($date)})
-             RETURN vs.personsVaccinated1PlusDose AS totalVaccinated`,
+			`MATCH (c:Country {code: $countryCode})-[:VACCINATED_ON]->(vs:VaccinationStats)
+             `+where+`
+             RETURN vs.personsVaccinated1PlusDose AS totalVaccinated, toString(vs.dateUpdated) AS date
+             `+orderBy+`
+             LIMIT 1`,
 			map[string]interface{}{
-				"countryCode": country,
+				"countryCode": countries.Canonical(country),
 				"date":        date,
+				"match":       match,
 			})
 
 		if err != nil {
@@ -39,8 +49,10 @@ func VaccinatedHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 		if result.Next(ctx) {
 			record := result.Record()
 			totalVaccinated, _ := record.Get("totalVaccinated")
+			reportDate, _ := record.Get("date")
 			response := map[string]interface{}{
 				"totalVaccinated": totalVaccinated,
+				"date":            reportDate,
 			}
 			json.NewEncoder(w).Encode(response)
 		} else {
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func VaccineDistributionHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		region := r.URL.Query().Get("region")
+		date := r.URL.Query().Get("date")
+
+		if region == "" {
+			http.Error(w, "Missing 'region' parameter", http.StatusBadRequest)
+			return
+		}
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// A participação (share) é sobre todos os países da região, inclusive os
+		// que não informaram vacinas
+		result, err := session.Run(ctx,
+			`MATCH (r:Region {name: $region})<-[:BELONGS]-(c:Country)
+             WITH collect(c) AS regionCountries
+             UNWIND regionCountries AS c
+             MATCH (c)-[u:USES]->(v:Vaccine)
+             WHERE `+usesActiveOnDate+`
+             WITH size(regionCountries) AS regionTotal, v.product AS vaccine,
+                  collect(DISTINCT v.company) AS companies, collect(DISTINCT c.code) AS countries
+             RETURN vaccine, companies, size(countries) AS usage, toFloat(size(countries)) / regionTotal AS share,
+                    countries, regionTotal
+             ORDER BY usage DESC, vaccine`,
+			map[string]interface{}{
+				"region": region,
+				"date":   date,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var regionTotal interface{}
+		var vaccines []map[string]interface{}
+		for result.Next(ctx) {
+			record := result.Record()
+			regionTotal, _ = record.Get("regionTotal")
+			vaccine := record.AsMap()
+			delete(vaccine, "regionTotal")
+			vaccines = append(vaccines, vaccine)
+		}
+		if len(vaccines) == 0 {
+			http.Error(w, "No data found", http.StatusNotFound)
+			return
+		}
+
+		// Vacinas com o mesmo uso recebem o mesmo rank e são marcadas como empatadas
+		rankTies(vaccines, "usage")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"region":    region,
+			"date":      date,
+			"countries": regionTotal,
+			"vaccines":  vaccines,
+		})
+	}
+}
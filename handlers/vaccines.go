@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Datas de autorização da vacina v, em ordem cronológica
+const vaccineAuthorizationDates = `CALL {
+                 WITH v
+                 OPTIONAL MATCH (v)-[:AUTHORIZATION_ON]->(d:Date)
+                 WITH d ORDER BY d.date
+                 RETURN collect(toString(d.date)) AS authorizationDates
+             }`
+
+func VaccinesHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		result, err := session.Run(ctx,
+			`MATCH (v:Vaccine)
+             `+vaccineAuthorizationDates+`
+             CALL {
+                 WITH v
+                 OPTIONAL MATCH (c:Country)-[u:USES]->(v)
+                 RETURN count(DISTINCT c) AS countries, toString(min(u.startDate)) AS firstStartDate,
+                        toString(max(u.startDate)) AS lastStartDate
+             }
+             CALL {
+                 WITH v
+                 OPTIONAL MATCH (r:Region)<-[:BELONGS]-(c:Country)-[:USES]->(v)
+                 WITH r, count(DISTINCT c) AS adopters ORDER BY adopters DESC, r.name
+                 RETURN collect(CASE WHEN r IS NOT NULL THEN {region: r.name, countries: adopters} END) AS regions
+             }
+             RETURN v.product AS product, v.vaccine AS vaccine, v.company AS company, authorizationDates,
+                    countries, firstStartDate, lastStartDate, regions
+             ORDER BY product`,
+			nil)
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		var vaccines []map[string]interface{}
+		for result.Next(ctx) {
+			vaccines = append(vaccines, result.Record().AsMap())
+		}
+		if len(vaccines) > 0 {
+			json.NewEncoder(w).Encode(vaccines)
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
+
+// VaccineHandler atende a rota /vaccines/{product}. O nome do produto pode
+// conter espaços e barras, então deve vir codificado na URL
+func VaccineHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := pathSegments(r.URL.EscapedPath(), "/vaccines/")
+		if len(segments) != 1 {
+			http.NotFound(w, r)
+			return
+		}
+		product, err := url.PathUnescape(segments[0])
+		if err != nil {
+			http.Error(w, "Invalid 'product' parameter", http.StatusBadRequest)
+			return
+		}
+
+		ctx := context.Background()
+		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+		defer session.Close(ctx)
+
+		// A participação (share) de cada região é sobre todos os seus países,
+		// inclusive os que não informaram vacinas
+		result, err := session.Run(ctx,
+			`MATCH (v:Vaccine {product: $product})
+             `+vaccineAuthorizationDates+`
+             CALL {
+                 WITH v
+                 OPTIONAL MATCH (c:Country)-[u:USES]->(v)
+                 WITH c, u ORDER BY u.startDate, c.code
+                 RETURN collect(CASE WHEN c IS NOT NULL THEN {code: c.code, name: c.name,
+                                     region: head([(c)-[:BELONGS]->(r:Region) | r.name]),
+                                     startDate: toString(u.startDate), endDate: toString(u.endDate)} END) AS countries,
+                        toString(min(u.startDate)) AS firstStartDate, toString(max(u.startDate)) AS lastStartDate
+             }
+             CALL {
+                 WITH v
+                 MATCH (r:Region)<-[:BELONGS]-(c:Country)
+                 WITH r, count(c) AS regionTotal, count(CASE WHEN EXISTS { (c)-[:USES]->(v) } THEN c END) AS adopters
+                 WHERE adopters > 0
+                 WITH r, regionTotal, adopters ORDER BY adopters DESC, r.name
+                 RETURN collect({region: r.name, countries: adopters, share: toFloat(adopters) / regionTotal}) AS regions
+             }
+             RETURN v.product AS product, v.vaccine AS vaccine, v.company AS company, authorizationDates,
+                    firstStartDate, lastStartDate, countries, regions`,
+			map[string]interface{}{
+				"product": product,
+			})
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not query data: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		if result.Next(ctx) {
+			json.NewEncoder(w).Encode(result.Record().AsMap())
+		} else {
+			http.Error(w, "No data found", http.StatusNotFound)
+		}
+	}
+}
@@ -6,27 +6,42 @@ import (
 	"fmt"
 	"net/http"
 
+	"desafiogolang-neo4j/countries"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
+// Condição das relações USES (u) em uso na data $date; sem data, aceita todas
+const usesActiveOnDate = `($date = "" OR (u.startDate <= date($date) AND (u.endDate IS NULL OR u.endDate >= date($date))))`
+
 func VaccinesUsedHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		country := r.URL.Query().Get("country")
+		date := r.URL.Query().Get("date")
 
 		if country == "" {
 			http.Error(w, "Missing 'country' parameter", http.StatusBadRequest)
 			return
 		}
+		if !validDate(date) {
+			http.Error(w, "Invalid 'date' parameter", http.StatusBadRequest)
+			return
+		}
 
 		ctx := context.Background()
 		session := driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 		defer session.Close(ctx)
 
+		// Com o parâmetro date, retorna apenas as vacinas em uso na data
 		result, err := session.Run(ctx,
-			`MATCH (c:Country {code: $countryCode})-[:USES]->(v:Vaccine)-[:STARTED_ON]->(d:Date)
-             RETURN v.product AS vaccine, toString(d.date) AS startDate`,
+			`MATCH (c:Country {code: $countryCode})-[u:USES]->(v:Vaccine)
+             WHERE `+usesActiveOnDate+`
+             RETURN v.product AS vaccine, toString(u.startDate) AS startDate, toString(u.endDate) AS endDate,
+                    u.dataSource AS dataSource, u.comment AS comment
+             ORDER BY u.startDate, v.product`,
 			map[string]interface{}{
-				"countryCode": country,
+				"countryCode": countries.Canonical(country),
+				"date":        date,
 			})
 
 		if err != nil {
@@ -39,9 +54,15 @@ func VaccinesUsedHandler(driver neo4j.DriverWithContext) http.HandlerFunc {
 			record := result.Record()
 			vaccine, _ := record.Get("vaccine")
 			startDate, _ := record.Get("startDate")
+			endDate, _ := record.Get("endDate")
+			dataSource, _ := record.Get("dataSource")
+			comment, _ := record.Get("comment")
 			vaccineData := map[string]interface{}{
-				"vaccine":   vaccine,
-				"startDate": startDate,
+				"vaccine":    vaccine,
+				"startDate":  startDate,
+				"endDate":    endDate,
+				"dataSource": dataSource,
+				"comment":    comment,
 			}
 			vaccines = append(vaccines, vaccineData)
 		}
@@ -0,0 +1,111 @@
+package loader
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Mapeia o nome de cada coluna do cabeçalho para sua posição no registro.
+// Os nomes são comparados sem diferenciar maiúsculas e minúsculas.
+type columns map[string]int
+
+// Lê o cabeçalho e garante que todas as colunas obrigatórias estão presentes.
+// Colunas extras, inclusive sem nome, são ignoradas.
+func newColumns(header []string, required []string) (columns, error) {
+	cols := columns{}
+	for i, name := range header {
+		key := normalizeColumn(name)
+		if key == "" {
+			continue
+		}
+		if _, ok := cols[key]; !ok {
+			cols[key] = i
+		}
+	}
+
+	var missing []string
+	for _, name := range required {
+		if _, ok := cols[normalizeColumn(name)]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+	return cols, nil
+}
+
+func normalizeColumn(name string) string {
+	return strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
+
+// Um registro do CSV acompanhado do mapeamento de colunas do arquivo
+type csvRow struct {
+	cols   columns
+	record []string
+}
+
+// Retorna o valor da coluna pelo nome, ou "" se ela não existir no registro
+func (r csvRow) get(name string) string {
+	i, ok := r.cols[normalizeColumn(name)]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return r.record[i]
+}
+
+// Percorre o CSV linha a linha, sem carregar o arquivo inteiro em memória,
+// com os campos separados por comma. O cabeçalho é validado contra as
+// colunas obrigatórias e handle recebe o número da linha no arquivo. Linhas
+// malformadas (e.g. com campos a mais) são repassadas para onError, que
+// decide se a leitura continua. Retorna a quantidade de linhas lidas,
+// incluindo as malformadas.
+func streamCSV(filePath string, comma rune, required []string, handle func(line int, row csvRow) error, onError func(line int, err error) error) (int, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader := csv.NewReader(f)
+	reader.Comma = comma
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return 0, err
+	}
+	cols, err := newColumns(header, required)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && onError != nil {
+			count++
+			if err := onError(parseErr.StartLine, err); err != nil {
+				return count, err
+			}
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		line, _ := reader.FieldPos(0)
+		if err := handle(line, csvRow{cols: cols, record: record}); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
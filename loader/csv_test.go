@@ -0,0 +1,86 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that columns are resolved by header name regardless of their position
+func TestNewColumns(t *testing.T) {
+	cols, err := newColumns([]string{"Country", "Date_reported", "New_cases", ""}, []string{"Date_reported", "country"})
+	assert.NoError(t, err)
+
+	row := csvRow{cols: cols, record: []string{"Brazil", "05/01/2020", "10", ""}}
+	assert.Equal(t, "05/01/2020", row.get("Date_reported"))
+	assert.Equal(t, "Brazil", row.get("COUNTRY"))
+	assert.Equal(t, "10", row.get("New_cases"))
+	assert.Equal(t, "", row.get("Cumulative_cases"))
+}
+
+// Tests that every missing required header is listed in the error
+func TestNewColumns_MissingRequired(t *testing.T) {
+	_, err := newColumns([]string{"Country"}, []string{"Country", "Date_reported", "New_cases"})
+	assert.EqualError(t, err, "missing required columns: Date_reported, New_cases")
+}
+
+// Tests streaming a file with reordered and extra columns
+func TestStreamCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	content := "\ufeffNew_cases;Date_reported;Extra;\n1;05/01/2020;x;\n2;12/01/2020;y;\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var lines []int
+	var dates []string
+	count, err := streamCSV(path, ';', []string{"Date_reported", "New_cases"}, func(line int, row csvRow) error {
+		lines = append(lines, line)
+		dates = append(dates, row.get("Date_reported"))
+		return nil
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []int{2, 3}, lines)
+	assert.Equal(t, []string{"05/01/2020", "12/01/2020"}, dates)
+}
+
+// Tests streaming a comma separated file with quoted fields
+func TestStreamCSV_Comma(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	content := "ISO3_code,Location,PopTotal\nCIV,\"Côte d'Ivoire, Republic of\",27478.249\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var locations []string
+	count, err := streamCSV(path, ',', []string{"ISO3_code", "PopTotal"}, func(line int, row csvRow) error {
+		locations = append(locations, row.get("Location"))
+		return nil
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []string{"Côte d'Ivoire, Republic of"}, locations)
+}
+
+// Tests that malformed rows are reported to onError and the reading continues
+func TestStreamCSV_MalformedRow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	content := "Country;ISO3;\nAruba;ABW;\nPalestine; including east Jerusalem;PSE;\nBrazil;BRA;\n"
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	var codes []string
+	var errorLines []int
+	count, err := streamCSV(path, ';', []string{"ISO3"}, func(line int, row csvRow) error {
+		codes = append(codes, row.get("ISO3"))
+		return nil
+	}, func(line int, err error) error {
+		errorLines = append(errorLines, line)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, []string{"ABW", "BRA"}, codes)
+	assert.Equal(t, []int{3}, errorLines)
+}
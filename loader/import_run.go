@@ -0,0 +1,160 @@
+package loader
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Uma execução de carga de um arquivo, registrada no grafo como um nó
+// ImportRun ligado pela relação IMPORTED aos nós que criou ou atualizou. O
+// watermark é a maior data carregada do arquivo e permite que as próximas
+// execuções do mesmo arquivo carreguem apenas as linhas mais novas.
+type importRun struct {
+	id        string
+	dataset   string
+	source    string
+	watermark string // Watermark das execuções anteriores; vazio na carga completa
+	maxDate   string // Maior data carregada nesta execução
+	minReject string // Menor data das linhas rejeitadas nesta execução
+	read      int    // Linhas lidas do arquivo
+	written   int    // Linhas gravadas no banco
+	rejected  int    // Linhas rejeitadas por valores inválidos
+	skipped   int    // Linhas ignoradas, e.g. por não serem mais novas que o watermark
+}
+
+// Cria o nó ImportRun da execução e busca o watermark das execuções
+// concluídas do mesmo conjunto de dados e do mesmo arquivo (source), a menos
+// que full seja verdadeiro. Assim a carga de outro arquivo do conjunto não
+// ignora as linhas mais antigas que o watermark do primeiro.
+func startImportRun(ctx context.Context, session neo4j.SessionWithContext, dataset, source string, full bool) (*importRun, error) {
+	source = filepath.Clean(source)
+	run := &importRun{dataset: dataset, source: source}
+
+	checksum, err := fileSHA256(source)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash %s: %w", source, err)
+	}
+
+	if !full {
+		result, err := session.Run(ctx,
+			`MATCH (i:ImportRun {dataset: $dataset, source: $source, status: "completed"})
+             RETURN max(i.watermark) AS watermark`,
+			map[string]interface{}{"dataset": dataset, "source": source})
+		if err != nil {
+			return nil, fmt.Errorf("could not read watermark: %w", err)
+		}
+		record, err := result.Single(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("could not read watermark: %w", err)
+		}
+		if watermark, ok := record.Values[0].(string); ok {
+			run.watermark = watermark
+		}
+	}
+
+	result, err := session.Run(ctx,
+		`CREATE (i:ImportRun {id: randomUUID(), dataset: $dataset, source: $source, sha256: $sha256, status: "running", startedAt: datetime()})
+         RETURN i.id AS id`,
+		map[string]interface{}{"dataset": dataset, "source": source, "sha256": checksum})
+	if err != nil {
+		return nil, fmt.Errorf("could not create import run: %w", err)
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not create import run: %w", err)
+	}
+	run.id = record.Values[0].(string)
+	return run, nil
+}
+
+// Indica se a linha com a data informada já foi carregada por uma execução
+// anterior. Uma linha sem data válida nunca é considerada carregada, para que
+// seja rejeitada em vez de ignorada
+func (r *importRun) loaded(date string) bool {
+	return r.watermark != "" && date != "" && date <= r.watermark
+}
+
+// Registra uma data carregada nesta execução
+func (r *importRun) track(date string) {
+	if date > r.maxDate {
+		r.maxDate = date
+	}
+}
+
+// Registra a data de uma linha rejeitada nesta execução
+func (r *importRun) trackReject(date string) {
+	if date != "" && (r.minReject == "" || date < r.minReject) {
+		r.minReject = date
+	}
+}
+
+// Calcula o watermark ao final da execução: a maior data carregada, mas
+// nunca a data de uma linha rejeitada ou posterior a ela, para que a
+// próxima execução incremental tente carregá-la novamente
+func (r *importRun) nextWatermark() string {
+	watermark := r.watermark
+	if r.maxDate > watermark {
+		watermark = r.maxDate
+	}
+	if r.minReject != "" && r.minReject <= watermark {
+		rejected, err := time.Parse("2006-01-02", r.minReject)
+		if err == nil {
+			watermark = rejected.AddDate(0, 0, -1).Format("2006-01-02")
+		}
+	}
+	return watermark
+}
+
+// Encerra a execução, avançando o watermark apenas se a carga foi concluída
+func (r *importRun) finish(ctx context.Context, session neo4j.SessionWithContext, loadErr error) error {
+	status := "completed"
+	if loadErr != nil {
+		status = "failed"
+	}
+
+	watermark := r.nextWatermark()
+
+	result, err := session.Run(ctx,
+		`MATCH (i:ImportRun {id: $id})
+         SET i.status = $status, i.finishedAt = datetime(), i.watermark = $watermark,
+             i.rowsRead = $read, i.rowsWritten = $written, i.rowsRejected = $rejected, i.rowsSkipped = $skipped`,
+		map[string]interface{}{
+			"id":        r.id,
+			"status":    status,
+			"watermark": nullIfEmpty(watermark),
+			"read":      r.read,
+			"written":   r.written,
+			"rejected":  r.rejected,
+			"skipped":   r.skipped,
+		})
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("could not finish import run: %w", err)
+	}
+	return nil
+}
+
+// Calcula o SHA-256 do arquivo, identificando a versão exata carregada
+func fileSHA256(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,46 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that only rows dated up to the watermark count as already loaded, and
+// that a row without a valid date never does
+func TestImportRun_Loaded(t *testing.T) {
+	run := &importRun{}
+	assert.False(t, run.loaded("2023-01-01"))
+
+	run.watermark = "2023-01-01"
+	assert.True(t, run.loaded("2022-12-31"))
+	assert.True(t, run.loaded("2023-01-01"))
+	assert.False(t, run.loaded("2023-01-02"))
+	assert.False(t, run.loaded(""))
+}
+
+// Tests that the watermark advances to the newest loaded date
+func TestImportRun_NextWatermark(t *testing.T) {
+	run := &importRun{watermark: "2023-01-01"}
+	assert.Equal(t, "2023-01-01", run.nextWatermark())
+
+	run.track("2023-01-15")
+	run.track("2023-01-08")
+	assert.Equal(t, "2023-01-15", run.nextWatermark())
+}
+
+// Tests that the watermark stops before the first rejected row, so the
+// next incremental run retries it
+func TestImportRun_NextWatermarkRejected(t *testing.T) {
+	run := &importRun{watermark: "2023-01-01"}
+	run.track("2023-01-15")
+	run.trackReject("2023-01-08")
+	run.trackReject("")
+	assert.Equal(t, "2023-01-07", run.nextWatermark())
+
+	// A rejected row after the newest loaded date does not hold the watermark back
+	run = &importRun{}
+	run.track("2023-01-08")
+	run.trackReject("2023-01-15")
+	assert.Equal(t, "2023-01-08", run.nextWatermark())
+}
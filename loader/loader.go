@@ -0,0 +1,602 @@
+// Package loader carrega no Neo4j os arquivos CSV de Covid-19 publicados pela OMS.
+package loader
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"desafiogolang-neo4j/countries"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	// Quantidade de linhas enviadas por transação
+	DefaultBatchSize = 1000
+	// Linhas rejeitadas toleradas no modo lenient antes de abortar
+	DefaultMaxErrors   = 100
+	DefaultRejectsFile = "rejects.csv"
+	// Ano da população carregada do World Population Prospects
+	DefaultPopulationYear = 2021
+)
+
+// Colunas obrigatórias de cada arquivo, resolvidas pelo nome no cabeçalho
+var (
+	globalDataColumns = []string{
+		"Date_reported", "Country_code", "Country", "WHO_region",
+		"New_cases", "Cumulative_cases", "New_deaths", "Cumulative_deaths",
+	}
+	vaccinationMetadataColumns = []string{
+		"ISO3", "PRODUCT_NAME", "VACCINE_NAME", "COMPANY_NAME", "AUTHORIZATION_DATE", "START_DATE",
+	}
+	vaccinationDataColumns = []string{
+		"COUNTRY", "ISO3", "WHO_REGION", "DATE_UPDATED",
+		"TOTAL_VACCINATIONS", "PERSONS_VACCINATED_1PLUS_DOSE",
+		"TOTAL_VACCINATIONS_PER100", "PERSONS_VACCINATED_1PLUS_DOSE_PER100",
+		"PERSONS_LAST_DOSE", "PERSONS_LAST_DOSE_PER100",
+		"PERSONS_BOOSTER_ADD_DOSE", "PERSONS_BOOSTER_ADD_DOSE_PER100",
+	}
+	// Arquivo TotalPopulationBySex do World Population Prospects da ONU
+	populationColumns = []string{"ISO3_code", "Variant", "Time", "PopTotal"}
+)
+
+// Options configura uma execução do Loader
+type Options struct {
+	BatchSize      int    // Linhas gravadas por transação
+	Lenient        bool   // Rejeita linhas inválidas em vez de interromper a carga
+	MaxErrors      int    // Linhas rejeitadas toleradas no modo lenient (0 = sem limite)
+	RejectsFile    string // Relatório das linhas rejeitadas no modo lenient
+	Full           bool   // Ignora o watermark e recarrega todas as linhas
+	PopulationYear int    // Ano da população carregada por LoadPopulation
+}
+
+// DefaultOptions retorna as opções padrão de carga
+func DefaultOptions() Options {
+	return Options{
+		BatchSize:      DefaultBatchSize,
+		MaxErrors:      DefaultMaxErrors,
+		RejectsFile:    DefaultRejectsFile,
+		PopulationYear: DefaultPopulationYear,
+	}
+}
+
+// Loader carrega os arquivos CSV da OMS no grafo
+type Loader struct {
+	session        neo4j.SessionWithContext
+	batchSize      int
+	rejects        *rejectLog
+	full           bool
+	populationYear int
+}
+
+// New cria um Loader que grava pela sessão informada. Close deve ser chamado
+// ao final para exibir o resumo e fechar o arquivo de rejeitos.
+func New(session neo4j.SessionWithContext, opts Options) (*Loader, error) {
+	if opts.BatchSize <= 0 {
+		return nil, fmt.Errorf("batch size must be positive, got %d", opts.BatchSize)
+	}
+	if opts.MaxErrors < 0 {
+		return nil, fmt.Errorf("max errors must not be negative, got %d", opts.MaxErrors)
+	}
+
+	rejects, err := newRejectLog(opts.Lenient, opts.MaxErrors, opts.RejectsFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not create rejects file: %w", err)
+	}
+	return &Loader{
+		session:        session,
+		batchSize:      opts.BatchSize,
+		rejects:        rejects,
+		full:           opts.Full,
+		populationYear: opts.PopulationYear,
+	}, nil
+}
+
+// Close exibe o resumo das linhas rejeitadas e fecha o arquivo de rejeitos
+func (l *Loader) Close() error {
+	l.rejects.summary()
+	return l.rejects.close()
+}
+
+// ApplySchema cria os requisitos necessários para os nós e para otimização das consultas
+func ApplySchema(ctx context.Context, session neo4j.SessionWithContext) error {
+	constraints := []string{
+		`CREATE CONSTRAINT country_code_unique IF NOT EXISTS FOR (c:Country) REQUIRE c.code IS UNIQUE`,
+		`CREATE INDEX country_code_index IF NOT EXISTS FOR (c:Country) ON (c.code)`,
+		`CREATE CONSTRAINT date_unique IF NOT EXISTS FOR (d:Date) REQUIRE d.date IS UNIQUE`,
+		`CREATE INDEX date_index IF NOT EXISTS FOR (d:Date) ON (d.date)`,
+		`CREATE CONSTRAINT region_unique IF NOT EXISTS FOR (r:Region) REQUIRE r.name IS UNIQUE`,
+		`CREATE INDEX region_index IF NOT EXISTS FOR (r:Region) ON (r.name)`,
+		`CREATE CONSTRAINT vaccine_unique IF NOT EXISTS FOR (v:Vaccine) REQUIRE v.product IS UNIQUE`,
+		`CREATE INDEX vaccine_product_index IF NOT EXISTS FOR (v:Vaccine) ON (v.product)`,
+		`CREATE CONSTRAINT manufacturer_unique IF NOT EXISTS FOR (m:Manufacturer) REQUIRE m.name IS UNIQUE`,
+		`CREATE INDEX manufacturer_name_index IF NOT EXISTS FOR (m:Manufacturer) ON (m.name)`,
+		`CREATE INDEX covid_stats_index IF NOT EXISTS FOR (cs:CovidStats) ON (cs.countryCode, cs.date)`,
+		`CREATE INDEX vaccination_stats_index IF NOT EXISTS FOR (vs:VaccinationStats) ON (vs.countryCode, vs.dateUpdated)`,
+		`CREATE CONSTRAINT import_run_unique IF NOT EXISTS FOR (i:ImportRun) REQUIRE i.id IS UNIQUE`,
+		`CREATE INDEX import_run_dataset_index IF NOT EXISTS FOR (i:ImportRun) ON (i.dataset)`,
+	}
+
+	for _, constraint := range constraints {
+		fmt.Printf("Executing constraint: %s\n", constraint)
+		_, err := session.Run(ctx, constraint, nil)
+		if err != nil {
+			return fmt.Errorf("could not create constraint: %w", err)
+		}
+	}
+	return nil
+}
+
+// Acumula linhas e as grava no banco em lotes, cada lote em uma transação
+// gerenciada. Uma falha desfaz apenas o lote corrente.
+type batchWriter struct {
+	session neo4j.SessionWithContext
+	query   string
+	params  map[string]interface{} // Parâmetros comuns a todos os lotes
+	size    int
+	rows    []map[string]interface{}
+	written int
+}
+
+func newBatchWriter(session neo4j.SessionWithContext, query string, size int, params map[string]interface{}) *batchWriter {
+	return &batchWriter{
+		session: session,
+		query:   query,
+		params:  params,
+		size:    size,
+		rows:    make([]map[string]interface{}, 0, size),
+	}
+}
+
+// Adiciona uma linha ao lote, gravando-o quando atinge o tamanho configurado
+func (b *batchWriter) add(ctx context.Context, row map[string]interface{}) error {
+	b.rows = append(b.rows, row)
+	if len(b.rows) >= b.size {
+		return b.flush(ctx)
+	}
+	return nil
+}
+
+// Grava as linhas pendentes com um único UNWIND $rows
+func (b *batchWriter) flush(ctx context.Context) error {
+	if len(b.rows) == 0 {
+		return nil
+	}
+	_, err := b.session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		params := map[string]interface{}{"rows": b.rows}
+		for key, value := range b.params {
+			params[key] = value
+		}
+		result, err := tx.Run(ctx, b.query, params)
+		if err != nil {
+			return nil, err
+		}
+		return result.Consume(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	b.written += len(b.rows)
+	fmt.Printf("Wrote batch of %d rows (%d total)\n", len(b.rows), b.written)
+	b.rows = b.rows[:0]
+	return nil
+}
+
+func parseToInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func parseToFloat(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+// Adiciona à linha o código canônico (alfa-3) do país e seus demais códigos
+// ISO 3166, unificando em um único nó Country os arquivos que usam alfa-2 e
+// os que usam alfa-3.
+func withCountryCodes(code string, row map[string]interface{}) map[string]interface{} {
+	row["countryCode"] = countries.Canonical(code)
+	row["iso2"], row["iso3"], row["numeric"] = nil, nil, nil
+	if country, ok := countries.Lookup(code); ok {
+		row["iso2"] = country.ISO2
+		row["iso3"] = country.ISO3
+		row["numeric"] = nullIfEmpty(country.Numeric)
+	}
+	return row
+}
+
+// Separa uma lista de valores separados por vírgula, e.g. a coluna VACCINES_USED
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Converte valores vazios em nil, gravados como null pelo UNWIND
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+func formatDate(dateStr string) (string, error) {
+	if dateStr == "" {
+		return "", nil
+	}
+	parsedDate, err := time.Parse("02/01/2006", dateStr)
+	if err != nil {
+		return "", err
+	}
+	return parsedDate.Format("2006-01-02"), nil
+}
+
+// Converte os campos de uma linha, guardando o primeiro erro encontrado para
+// que a linha possa ser rejeitada com a coluna e o motivo.
+type fieldParser struct {
+	row csvRow
+	err error
+}
+
+func (p *fieldParser) fail(column, reason string) {
+	if p.err == nil {
+		p.err = &fieldError{column: column, reason: reason}
+	}
+}
+
+// Retorna o valor da coluna, rejeitando a linha se ele estiver vazio
+func (p *fieldParser) required(column string) string {
+	value := p.row.get(column)
+	if value == "" {
+		p.fail(column, "missing value")
+	}
+	return value
+}
+
+// Retorna o código alfa-3 do país. Um valor fora do formato indica que as
+// colunas da linha estão deslocadas (e.g. "China; Hong Kong SAR" no nome),
+// então a linha é rejeitada
+func (p *fieldParser) alpha3(column string) string {
+	value := strings.TrimSpace(p.row.get(column))
+	valid := len(value) == 3
+	for _, r := range value {
+		valid = valid && r >= 'A' && r <= 'Z'
+	}
+	if !valid {
+		p.fail(column, fmt.Sprintf("invalid alpha-3 code %q", p.row.get(column)))
+	}
+	return value
+}
+
+func (p *fieldParser) int(column string) int {
+	value := p.row.get(column)
+	n, err := parseToInt(value)
+	if err != nil {
+		p.fail(column, fmt.Sprintf("invalid integer %q", value))
+	}
+	return n
+}
+
+// Como int, mas retorna nil quando o valor está vazio
+func (p *fieldParser) nullableInt(column string) interface{} {
+	if p.row.get(column) == "" {
+		return nil
+	}
+	return p.int(column)
+}
+
+func (p *fieldParser) float(column string) float64 {
+	value := p.row.get(column)
+	n, err := parseToFloat(value)
+	if err != nil {
+		p.fail(column, fmt.Sprintf("invalid number %q", value))
+	}
+	return n
+}
+
+// Converte a data de DD/MM/AAAA para AAAA-MM-DD; vazia quando ausente
+func (p *fieldParser) date(column string) string {
+	value := p.row.get(column)
+	date, err := formatDate(value)
+	if err != nil {
+		p.fail(column, fmt.Sprintf("invalid date %q", value))
+	}
+	return date
+}
+
+// Descreve como carregar um conjunto de dados
+type dataset struct {
+	name    string
+	columns []string // Colunas obrigatórias do cabeçalho
+	query   string   // Query de escrita, executada com UNWIND $rows
+	comma   rune     // Separador dos campos; ';' quando não informado
+	// Parâmetro com a data de referência da linha (AAAA-MM-DD), usado na carga
+	// incremental. Vazio quando o arquivo é sempre recarregado por completo.
+	watermarkKey string
+	// Converte a linha em parâmetros da query; false ignora a linha
+	parse func(line int, p *fieldParser) (map[string]interface{}, bool)
+}
+
+// Lê o arquivo linha a linha, rejeitando as linhas inválidas e gravando as
+// demais em lotes. A execução é registrada em um nó ImportRun.
+func (l *Loader) load(ctx context.Context, filePath string, ds dataset) (err error) {
+	fmt.Printf("Loading data from file: %s\n", filePath)
+
+	run, err := startImportRun(ctx, l.session, ds.name, filePath, l.full || ds.watermarkKey == "")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if finishErr := run.finish(ctx, l.session, err); err == nil {
+			err = finishErr
+		}
+	}()
+	if run.watermark != "" {
+		fmt.Printf("Loading only rows newer than %s\n", run.watermark)
+	}
+
+	writer := newBatchWriter(l.session, ds.query, l.batchSize, map[string]interface{}{"runId": run.id})
+	reject := func(line int, err error) error {
+		run.rejected++
+		return l.rejects.reject(filePath, line, err)
+	}
+
+	comma := ds.comma
+	if comma == 0 {
+		comma = ';'
+	}
+	run.read, err = streamCSV(filePath, comma, ds.columns, func(line int, row csvRow) error {
+		p := &fieldParser{row: row}
+		params, ok := ds.parse(line, p)
+		if p.err != nil {
+			if ds.watermarkKey != "" {
+				date, _ := params[ds.watermarkKey].(string)
+				if run.loaded(date) {
+					run.skipped++
+					return nil
+				}
+				run.trackReject(date)
+			}
+			return reject(line, p.err)
+		}
+		if !ok {
+			run.skipped++
+			return nil
+		}
+		if ds.watermarkKey != "" {
+			date, _ := params[ds.watermarkKey].(string)
+			if run.loaded(date) {
+				run.skipped++
+				return nil
+			}
+			run.track(date)
+		}
+		return writer.add(ctx, params)
+	}, reject)
+	if err == nil {
+		err = writer.flush(ctx)
+	}
+	run.written = writer.written
+	if err != nil {
+		return fmt.Errorf("%s: %w", filePath, err)
+	}
+	fmt.Printf("Finished processing %d records from %s: %d written, %d rejected, %d skipped\n",
+		run.read, filePath, run.written, run.rejected, run.skipped)
+	return nil
+}
+
+// LoadGlobalData carrega os dados do arquivo WHO-COVID-19-global-data. A OMS
+// não informa a região de alguns territórios (e.g. FO e GF); esses países são
+// gravados sem a relação BELONGS.
+func (l *Loader) LoadGlobalData(ctx context.Context, filePath string) error {
+	query := `MATCH (run:ImportRun {id: $runId})
+         UNWIND $rows AS row
+         MERGE (c:Country {code: row.countryCode})
+         SET c.name = row.countryName, c.iso2 = row.iso2, c.iso3 = row.iso3, c.numeric = row.numeric
+         MERGE (d:Date {date: date(row.date)})
+         MERGE (cs:CovidStats {date: row.date, countryCode: row.countryCode})
+         SET cs.cumulativeCases = row.cumulativeCases, cs.cumulativeDeaths = row.cumulativeDeaths, cs.newCases = row.newCases, cs.newDeaths = row.newDeaths
+         FOREACH (_ IN CASE WHEN row.region IS NULL THEN [] ELSE [1] END |
+             MERGE (r:Region {name: row.region})
+             MERGE (c)-[:BELONGS]->(r)
+         )
+         MERGE (c)-[:REPORTED_ON]->(cs)
+         MERGE (cs)-[:ON_DATE]->(d)
+         MERGE (run)-[:IMPORTED]->(cs)`
+
+	return l.load(ctx, filePath, dataset{name: "global-data", columns: globalDataColumns, query: query, watermarkKey: "date", parse: func(line int, p *fieldParser) (map[string]interface{}, bool) {
+		countryCode := p.required("Country_code")
+		dateFormatted := p.date("Date_reported")
+		if dateFormatted == "" {
+			p.fail("Date_reported", "missing value")
+		}
+
+		return withCountryCodes(countryCode, map[string]interface{}{
+			"region":           nullIfEmpty(p.row.get("WHO_region")),
+			"countryName":      p.row.get("Country"),
+			"date":             dateFormatted,
+			"cumulativeCases":  p.int("Cumulative_cases"),
+			"cumulativeDeaths": p.int("Cumulative_deaths"),
+			"newCases":         p.int("New_cases"),
+			"newDeaths":        p.int("New_deaths"),
+		}), true
+	}})
+}
+
+// LoadVaccinationMetadata carrega os dados do arquivo vaccination-metadata. O
+// período de uso de cada vacina no país (START_DATE e END_DATE), a fonte e o
+// comentário ficam na relação USES, e o fabricante (COMPANY_NAME) vira um nó
+// Manufacturer ligado às suas vacinas.
+func (l *Loader) LoadVaccinationMetadata(ctx context.Context, filePath string) error {
+	query := `MATCH (run:ImportRun {id: $runId})
+         UNWIND $rows AS row
+         MERGE (v:Vaccine {product: row.productName, company: row.companyName, vaccine: row.vaccineName})
+         FOREACH (_ IN CASE WHEN row.companyName = "" THEN [] ELSE [1] END |
+             MERGE (m:Manufacturer {name: row.companyName})
+             MERGE (m)-[:PRODUCES]->(v)
+         )
+         MERGE (c:Country {code: row.countryCode})
+         SET c.name = coalesce(c.name, row.countryName), c.iso2 = row.iso2, c.iso3 = row.iso3, c.numeric = row.numeric
+         FOREACH (_ IN CASE WHEN row.authorizationDate IS NULL THEN [] ELSE [1] END |
+             MERGE (dAuth:Date {date: date(row.authorizationDate)})
+             MERGE (v)-[:AUTHORIZATION_ON]->(dAuth)
+         )
+         FOREACH (_ IN CASE WHEN row.startDate IS NULL THEN [] ELSE [1] END |
+             MERGE (dStart:Date {date: date(row.startDate)})
+             MERGE (v)-[:STARTED_ON]->(dStart)
+         )
+         MERGE (c)-[u:USES]->(v)
+         SET u.startDate = date(row.startDate), u.endDate = date(row.endDate),
+             u.dataSource = row.dataSource, u.comment = row.comment
+         MERGE (run)-[:IMPORTED]->(v)`
+
+	return l.load(ctx, filePath, dataset{name: "vaccination-metadata", columns: vaccinationMetadataColumns, query: query, parse: func(line int, p *fieldParser) (map[string]interface{}, bool) {
+		countryCode := p.alpha3("ISO3")
+		productName := p.row.get("PRODUCT_NAME")
+
+		if productName == "" {
+			fmt.Printf("Skipping line %d due to empty product name\n", line)
+			return nil, false
+		}
+
+		// O arquivo só traz o código do país; o nome vem da tabela ISO 3166 e
+		// nunca sobrescreve o nome já gravado pelos outros arquivos
+		var countryName interface{}
+		if country, ok := countries.Lookup(countryCode); ok {
+			countryName = country.Name
+		}
+
+		return withCountryCodes(countryCode, map[string]interface{}{
+			"countryName":       countryName,
+			"productName":       productName,
+			"vaccineName":       p.row.get("VACCINE_NAME"),
+			"companyName":       p.row.get("COMPANY_NAME"),
+			"authorizationDate": nullIfEmpty(p.date("AUTHORIZATION_DATE")),
+			"startDate":         nullIfEmpty(p.date("START_DATE")),
+			"endDate":           nullIfEmpty(p.date("END_DATE")),
+			"dataSource":        nullIfEmpty(p.row.get("DATA_SOURCE")),
+			"comment":           nullIfEmpty(p.row.get("COMMENT")),
+		}), true
+	}})
+}
+
+// LoadVaccinationData carrega os dados do arquivo vaccination-data. Deve ser
+// executado após LoadVaccinationMetadata, para que as vacinas de
+// VACCINES_USED encontrem os nós Vaccine correspondentes.
+func (l *Loader) LoadVaccinationData(ctx context.Context, filePath string) error {
+	query := `MATCH (run:ImportRun {id: $runId})
+         UNWIND $rows AS row
+         MERGE (c:Country {code: row.countryCode})
+         SET c.name = row.countryName, c.iso2 = row.iso2, c.iso3 = row.iso3, c.numeric = row.numeric
+         FOREACH (_ IN CASE WHEN row.region IS NULL THEN [] ELSE [1] END |
+             MERGE (r:Region {name: row.region})
+             MERGE (c)-[:BELONGS]->(r)
+         )
+         FOREACH (_ IN CASE WHEN row.dateUpdated IS NULL THEN [] ELSE [1] END |
+             MERGE (d:Date {date: date(row.dateUpdated)})
+             MERGE (vs:VaccinationStats {countryCode: row.countryCode, dateUpdated: date(row.dateUpdated)})
+             SET vs.totalVaccinations = row.totalVaccinations, vs.personsVaccinated1PlusDose = row.personsVaccinated1PlusDose,
+                 vs.totalVaccinationsPer100 = row.totalVaccinationsPer100, vs.personsVaccinated1PlusDosePer100 = row.personsVaccinated1PlusDosePer100,
+                 vs.personsLastDose = row.personsLastDose, vs.personsLastDosePer100 = row.personsLastDosePer100,
+                 vs.personsBoosterAddDose = row.personsBoosterAddDose, vs.personsBoosterAddDosePer100 = row.personsBoosterAddDosePer100,
+                 vs.numberVaccineTypesUsed = row.numberVaccineTypesUsed
+             MERGE (c)-[:VACCINATED_ON]->(vs)
+             MERGE (vs)-[:ON_DATE]->(d)
+             MERGE (run)-[:IMPORTED]->(vs)
+         )
+         FOREACH (_ IN CASE WHEN row.firstVaccineDate IS NULL THEN [] ELSE [1] END |
+             MERGE (dFirst:Date {date: date(row.firstVaccineDate)})
+             MERGE (c)-[:FIRST_VACCINATED_ON]->(dFirst)
+         )
+         WITH c, row
+         CALL {
+             WITH c, row
+             UNWIND row.vaccinesUsed AS vaccineName
+             MATCH (v:Vaccine)
+             WHERE v.vaccine = vaccineName OR v.product = vaccineName
+             MERGE (c)-[:USES]->(v)
+         }`
+
+	return l.load(ctx, filePath, dataset{name: "vaccination-data", columns: vaccinationDataColumns, query: query, parse: func(line int, p *fieldParser) (map[string]interface{}, bool) {
+		// DATE_UPDATED é a data de atualização do relatório do país. Linhas com o
+		// nome do país quebrado em duas colunas trazem o código no lugar da região
+		// e "REPORTING" nessa coluna, e são rejeitadas pela validação do ISO3 e da
+		// data. Sem a data não há como identificar o relatório, então só o país é
+		// gravado.
+		countryCode := p.alpha3("ISO3")
+		dateUpdated := p.date("DATE_UPDATED")
+
+		return withCountryCodes(countryCode, map[string]interface{}{
+			"region":                           nullIfEmpty(p.row.get("WHO_REGION")),
+			"countryName":                      p.row.get("COUNTRY"),
+			"dateUpdated":                      nullIfEmpty(dateUpdated),
+			"firstVaccineDate":                 nullIfEmpty(p.date("FIRST_VACCINE_DATE")),
+			"vaccinesUsed":                     splitList(p.row.get("VACCINES_USED")),
+			"numberVaccineTypesUsed":           p.nullableInt("NUMBER_VACCINES_TYPES_USED"),
+			"totalVaccinations":                p.float("TOTAL_VACCINATIONS"),
+			"personsVaccinated1PlusDose":       p.float("PERSONS_VACCINATED_1PLUS_DOSE"),
+			"totalVaccinationsPer100":          p.float("TOTAL_VACCINATIONS_PER100"),
+			"personsVaccinated1PlusDosePer100": p.float("PERSONS_VACCINATED_1PLUS_DOSE_PER100"),
+			"personsLastDose":                  p.float("PERSONS_LAST_DOSE"),
+			"personsLastDosePer100":            p.float("PERSONS_LAST_DOSE_PER100"),
+			"personsBoosterAddDose":            p.float("PERSONS_BOOSTER_ADD_DOSE"),
+			"personsBoosterAddDosePer100":      p.float("PERSONS_BOOSTER_ADD_DOSE_PER100"),
+		}), true
+	}})
+}
+
+// LoadPopulation carrega a população de cada país na propriedade population
+// do nó Country, usada nas métricas por habitante. O arquivo é o
+// TotalPopulationBySex do World Population Prospects da ONU, do qual são
+// usadas apenas as linhas dos países (com ISO3_code) na variante Medium e no
+// ano configurado; PopTotal vem em milhares de habitantes.
+func (l *Loader) LoadPopulation(ctx context.Context, filePath string) error {
+	query := `MATCH (run:ImportRun {id: $runId})
+         UNWIND $rows AS row
+         MERGE (c:Country {code: row.countryCode})
+         SET c.name = coalesce(c.name, row.countryName), c.iso2 = row.iso2, c.iso3 = row.iso3, c.numeric = row.numeric,
+             c.population = row.population, c.populationYear = row.year
+         MERGE (run)-[:IMPORTED]->(c)`
+
+	return l.load(ctx, filePath, dataset{name: "population", columns: populationColumns, query: query, comma: ',', parse: parsePopulation(l.populationYear)})
+}
+
+// Converte uma linha do World Population Prospects; as linhas de outras
+// variantes, de outros anos e das regiões agregadas são ignoradas
+func parsePopulation(year int) func(line int, p *fieldParser) (map[string]interface{}, bool) {
+	return func(line int, p *fieldParser) (map[string]interface{}, bool) {
+		if p.row.get("ISO3_code") == "" || p.row.get("Variant") != "Medium" || p.row.get("Time") != strconv.Itoa(year) {
+			return nil, false
+		}
+
+		countryCode := p.alpha3("ISO3_code")
+		population := int(math.Round(p.float("PopTotal") * 1000))
+		if population <= 0 {
+			p.fail("PopTotal", "population must be positive")
+		}
+
+		countryName := nullIfEmpty(p.row.get("Location"))
+		if country, ok := countries.Lookup(countryCode); ok {
+			countryName = country.Name
+		}
+
+		return withCountryCodes(countryCode, map[string]interface{}{
+			"countryName": countryName,
+			"population":  population,
+			"year":        year,
+		}), true
+	}
+}
@@ -0,0 +1,75 @@
+package loader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests splitting the VACCINES_USED column into vaccine names
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"AstraZeneca - AZD1222", "Pfizer BioNTech - Comirnaty"}, splitList("AstraZeneca - AZD1222, Pfizer BioNTech - Comirnaty,"))
+	assert.Equal(t, []string{}, splitList(""))
+}
+
+// Tests that rows with shifted columns are rejected by the ISO3 validation
+func TestFieldParser_Alpha3(t *testing.T) {
+	cols := columns{"ISO3": 0}
+
+	p := &fieldParser{row: csvRow{cols: cols, record: []string{"HKG"}}}
+	assert.Equal(t, "HKG", p.alpha3("ISO3"))
+	assert.NoError(t, p.err)
+
+	p = &fieldParser{row: csvRow{cols: cols, record: []string{" Hong Kong SAR"}}}
+	p.alpha3("ISO3")
+	assert.EqualError(t, p.err, "column ISO3: invalid alpha-3 code \" Hong Kong SAR\"")
+
+	p = &fieldParser{row: csvRow{cols: cols, record: []string{""}}}
+	p.alpha3("ISO3")
+	assert.EqualError(t, p.err, "column ISO3: invalid alpha-3 code \"\"")
+}
+
+// Tests that optional integer columns are null when empty
+func TestFieldParser_NullableInt(t *testing.T) {
+	cols := columns{"NUMBER_VACCINES_TYPES_USED": 0}
+
+	p := &fieldParser{row: csvRow{cols: cols, record: []string{""}}}
+	assert.Nil(t, p.nullableInt("NUMBER_VACCINES_TYPES_USED"))
+
+	p = &fieldParser{row: csvRow{cols: cols, record: []string{"3"}}}
+	assert.Equal(t, 3, p.nullableInt("NUMBER_VACCINES_TYPES_USED"))
+
+	p = &fieldParser{row: csvRow{cols: cols, record: []string{"three"}}}
+	p.nullableInt("NUMBER_VACCINES_TYPES_USED")
+	assert.EqualError(t, p.err, "column NUMBER_VACCINES_TYPES_USED: invalid integer \"three\"")
+}
+
+// Tests that only the medium variant of the configured year is loaded from
+// the World Population Prospects file
+func TestParsePopulation(t *testing.T) {
+	cols := columns{"ISO3_CODE": 0, "VARIANT": 1, "TIME": 2, "POPTOTAL": 3, "LOCATION": 4}
+	parse := parsePopulation(2021)
+
+	p := &fieldParser{row: csvRow{cols: cols, record: []string{"BRA", "Medium", "2021", "214326.223", "Brazil"}}}
+	row, ok := parse(2, p)
+	assert.True(t, ok)
+	assert.NoError(t, p.err)
+	assert.Equal(t, "BRA", row["countryCode"])
+	assert.Equal(t, 214326223, row["population"])
+	assert.Equal(t, 2021, row["year"])
+
+	for _, record := range [][]string{
+		{"BRA", "Medium", "2022", "215313.498", "Brazil"},
+		{"BRA", "High", "2021", "214326.223", "Brazil"},
+		{"", "Medium", "2021", "7909295.151", "World"},
+	} {
+		p = &fieldParser{row: csvRow{cols: cols, record: record}}
+		_, ok = parse(2, p)
+		assert.False(t, ok)
+		assert.NoError(t, p.err)
+	}
+
+	p = &fieldParser{row: csvRow{cols: cols, record: []string{"BRA", "Medium", "2021", "", "Brazil"}}}
+	parse(2, p)
+	assert.EqualError(t, p.err, "column PopTotal: population must be positive")
+}
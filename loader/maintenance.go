@@ -0,0 +1,185 @@
+package loader
+
+import (
+	"context"
+	"fmt"
+
+	"desafiogolang-neo4j/countries"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Rótulos e relações do modelo, usados pelas estatísticas do grafo
+var (
+	nodeLabels = []string{
+		"Country", "Region", "Date", "CovidStats", "VaccinationStats", "Vaccine", "Manufacturer", "ImportRun",
+	}
+	relationshipTypes = []string{
+		"BELONGS", "REPORTED_ON", "ON_DATE", "VACCINATED_ON", "USES", "STARTED_ON", "AUTHORIZATION_ON",
+		"FIRST_VACCINATED_ON", "PRODUCES", "IMPORTED",
+	}
+)
+
+// Count é a quantidade de nós de um rótulo ou de relações de um tipo
+type Count struct {
+	Name  string
+	Count int64
+}
+
+// Check é o resultado de uma verificação de integridade: a quantidade de
+// elementos que violam a regra descrita em Name
+type Check struct {
+	Name     string
+	Problems int64
+}
+
+// Verificações executadas por Verify; cada query retorna a quantidade de problemas
+var checks = []struct {
+	name  string
+	query string
+}{
+	{
+		"CovidStats without a reporting country",
+		`MATCH (cs:CovidStats) WHERE NOT (cs)<-[:REPORTED_ON]-(:Country) RETURN count(cs)`,
+	},
+	{
+		"CovidStats without a date",
+		`MATCH (cs:CovidStats) WHERE NOT (cs)-[:ON_DATE]->(:Date) RETURN count(cs)`,
+	},
+	{
+		"VaccinationStats without a country",
+		`MATCH (vs:VaccinationStats) WHERE NOT (vs)<-[:VACCINATED_ON]-(:Country) RETURN count(vs)`,
+	},
+	{
+		"Regions with an empty name",
+		`MATCH (r:Region) WHERE r.name = "" RETURN count(r)`,
+	},
+	{
+		"Vaccines without a manufacturer",
+		`MATCH (v:Vaccine) WHERE v.company <> "" AND NOT (v)<-[:PRODUCES]-(:Manufacturer) RETURN count(v)`,
+	},
+	{
+		"Countries with a legacy two-letter code",
+		`MATCH (c:Country) WHERE size(c.code) = 2 RETURN count(c)`,
+	},
+	{
+		"Countries sharing the same ISO2 code",
+		`MATCH (c:Country) WHERE c.iso2 IS NOT NULL
+         WITH c.iso2 AS iso2, count(c) AS total WHERE total > 1
+         RETURN count(iso2)`,
+	},
+	{
+		"Decreasing cumulative cases between reports",
+		`MATCH (c:Country)-[:REPORTED_ON]->(cs:CovidStats)
+         WITH c, cs ORDER BY cs.date
+         WITH c, collect(cs.cumulativeCases) AS values
+         RETURN coalesce(sum(size([i IN range(1, size(values) - 1) WHERE values[i] < values[i - 1]])), 0)`,
+	},
+	{
+		"Import runs not completed",
+		`MATCH (i:ImportRun) WHERE i.status <> "completed" RETURN count(i)`,
+	},
+}
+
+// Stats conta os nós de cada rótulo e as relações de cada tipo do modelo
+func Stats(ctx context.Context, session neo4j.SessionWithContext) (nodes []Count, relationships []Count, err error) {
+	for _, label := range nodeLabels {
+		count, err := single(ctx, session, fmt.Sprintf("MATCH (n:%s) RETURN count(n)", label))
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes = append(nodes, Count{Name: label, Count: count})
+	}
+	for _, relType := range relationshipTypes {
+		count, err := single(ctx, session, fmt.Sprintf("MATCH ()-[r:%s]->() RETURN count(r)", relType))
+		if err != nil {
+			return nil, nil, err
+		}
+		relationships = append(relationships, Count{Name: relType, Count: count})
+	}
+	return nodes, relationships, nil
+}
+
+// Verify executa as verificações de integridade do grafo
+func Verify(ctx context.Context, session neo4j.SessionWithContext) ([]Check, error) {
+	var results []Check
+	for _, check := range checks {
+		problems, err := single(ctx, session, check.query)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", check.name, err)
+		}
+		results = append(results, Check{Name: check.name, Problems: problems})
+	}
+	return results, nil
+}
+
+// Wipe remove todos os nós e relações do banco, em transações de 10000 nós
+func Wipe(ctx context.Context, session neo4j.SessionWithContext) error {
+	result, err := session.Run(ctx,
+		`MATCH (n)
+         CALL { WITH n DETACH DELETE n } IN TRANSACTIONS OF 10000 ROWS`,
+		nil)
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	return err
+}
+
+// MigrateLegacyCountries remove os países gravados pela carga antiga, que
+// usava o código alfa-2 do arquivo WHO-COVID-19-global-data como code, junto
+// com as suas estatísticas. Esses nós duplicariam os países identificados
+// pelo código alfa-3; a próxima carga recria os dados deles. Códigos de 2
+// letras fora da tabela ISO 3166-1 não têm um país correspondente e são
+// mantidos. Retorna a quantidade de países removidos.
+func MigrateLegacyCountries(ctx context.Context, session neo4j.SessionWithContext) (int, error) {
+	result, err := session.Run(ctx, `MATCH (c:Country) WHERE size(c.code) = 2 RETURN c.code AS code`, nil)
+	if err != nil {
+		return 0, err
+	}
+	records, err := result.Collect(ctx)
+	if err != nil {
+		return 0, err
+	}
+	codes := []string{}
+	for _, record := range records {
+		code, _ := record.Values[0].(string)
+		if _, ok := countries.Lookup(code); ok {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return 0, nil
+	}
+
+	result, err = session.Run(ctx,
+		`MATCH (c:Country) WHERE c.code IN $codes
+         CALL {
+             WITH c
+             OPTIONAL MATCH (c)-[:REPORTED_ON|VACCINATED_ON]->(s)
+             WITH c, collect(s) AS stats
+             FOREACH (s IN stats | DETACH DELETE s)
+             DETACH DELETE c
+         } IN TRANSACTIONS OF 10 ROWS`,
+		map[string]interface{}{"codes": codes})
+	if err == nil {
+		_, err = result.Consume(ctx)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(codes), nil
+}
+
+// Executa uma query que retorna um único inteiro
+func single(ctx context.Context, session neo4j.SessionWithContext, query string) (int64, error) {
+	result, err := session.Run(ctx, query, nil)
+	if err != nil {
+		return 0, err
+	}
+	record, err := result.Single(ctx)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := record.Values[0].(int64)
+	return count, nil
+}
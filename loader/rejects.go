@@ -0,0 +1,115 @@
+package loader
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Erro de conversão de um campo específico da linha
+type fieldError struct {
+	column string
+	reason string
+}
+
+func (e *fieldError) Error() string {
+	return fmt.Sprintf("column %s: %s", e.column, e.reason)
+}
+
+// Registra as linhas rejeitadas durante a carga. No modo estrito a primeira
+// linha inválida interrompe a carga; no modo tolerante ela é ignorada e
+// gravada no arquivo de rejeitos, até o limite de maxErrors.
+type rejectLog struct {
+	lenient   bool
+	maxErrors int
+	path      string
+	file      *os.File
+	writer    *csv.Writer
+	counts    map[string]int
+	total     int
+}
+
+func newRejectLog(lenient bool, maxErrors int, path string) (*rejectLog, error) {
+	r := &rejectLog{lenient: lenient, maxErrors: maxErrors, path: path, counts: map[string]int{}}
+	if !lenient {
+		return r, nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r.file = file
+	r.writer = csv.NewWriter(file)
+	r.writer.Comma = ';'
+	if err := r.writer.Write([]string{"source", "line", "column", "reason"}); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Rejeita a linha do arquivo source. Devolve um erro quando a carga deve ser
+// interrompida: no modo estrito ou quando o limite de erros é ultrapassado.
+func (r *rejectLog) reject(source string, line int, err error) error {
+	if !r.lenient {
+		return fmt.Errorf("line %d: %w", line, err)
+	}
+
+	column, reason := "", err.Error()
+	var fe *fieldError
+	var pe *csv.ParseError
+	if errors.As(err, &fe) {
+		column, reason = fe.column, fe.reason
+	} else if errors.As(err, &pe) {
+		reason = pe.Err.Error()
+	}
+
+	if err := r.writer.Write([]string{source, strconv.Itoa(line), column, reason}); err != nil {
+		return err
+	}
+	r.counts[source]++
+	r.total++
+
+	if r.maxErrors > 0 && r.total > r.maxErrors {
+		return fmt.Errorf("too many rejected rows: %d exceeds the maximum of %d", r.total, r.maxErrors)
+	}
+	return nil
+}
+
+// Imprime o resumo das linhas rejeitadas por arquivo
+func (r *rejectLog) summary() {
+	if !r.lenient {
+		return
+	}
+	if r.total == 0 {
+		fmt.Println("No rows were rejected")
+		return
+	}
+
+	sources := make([]string, 0, len(r.counts))
+	for source := range r.counts {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	fmt.Printf("Rejected %d rows, see %s\n", r.total, r.path)
+	for _, source := range sources {
+		fmt.Printf("  %s: %d rows\n", source, r.counts[source])
+	}
+}
+
+func (r *rejectLog) close() error {
+	if r.file == nil {
+		return nil
+	}
+	r.writer.Flush()
+	if err := r.writer.Error(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
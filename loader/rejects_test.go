@@ -0,0 +1,42 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Tests that the strict mode stops at the first invalid row
+func TestRejectLog_Strict(t *testing.T) {
+	rejects, err := newRejectLog(false, 0, "")
+	assert.NoError(t, err)
+
+	err = rejects.reject("data.csv", 7, &fieldError{column: "New_cases", reason: "invalid integer \"2.30E+07\""})
+	assert.EqualError(t, err, "line 7: column New_cases: invalid integer \"2.30E+07\"")
+}
+
+// Tests that the lenient mode records rejected rows until the threshold
+func TestRejectLog_Lenient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rejects.csv")
+	rejects, err := newRejectLog(true, 2, path)
+	assert.NoError(t, err)
+
+	row := csvRow{cols: columns{"NEW_CASES": 0}, record: []string{"2.30E+07"}}
+	p := &fieldParser{row: row}
+	p.int("New_cases")
+
+	assert.NoError(t, rejects.reject("data.csv", 2, p.err))
+	assert.NoError(t, rejects.reject("data.csv", 5, &fieldError{column: "Date_reported", reason: "missing value"}))
+	assert.Error(t, rejects.reject("other.csv", 9, &fieldError{column: "ISO3", reason: "missing value"}))
+	assert.NoError(t, rejects.close())
+
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "source;line;column;reason\n"+
+		"data.csv;2;New_cases;\"invalid integer \"\"2.30E+07\"\"\"\n"+
+		"data.csv;5;Date_reported;missing value\n"+
+		"other.csv;9;ISO3;missing value\n", string(content))
+	assert.Equal(t, map[string]int{"data.csv": 2, "other.csv": 1}, rejects.counts)
+}
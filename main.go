@@ -30,6 +30,19 @@ func main() {
 	http.HandleFunc("/vaccines-used", handlers.VaccinesUsedHandler(driver))
 	http.HandleFunc("/highest-cases", handlers.HighestCasesHandler(driver))
 	http.HandleFunc("/most-used-vaccine", handlers.MostUsedVaccineHandler(driver))
+	http.HandleFunc("/vaccine-distribution", handlers.VaccineDistributionHandler(driver))
+	http.HandleFunc("/vaccines", handlers.VaccinesHandler(driver))
+	http.HandleFunc("/vaccines/", handlers.VaccineHandler(driver))
+	http.HandleFunc("/manufacturers", handlers.ManufacturersHandler(driver))
+	http.HandleFunc("/manufacturers/", handlers.ManufacturerHandler(driver))
+	http.HandleFunc("/ranking", handlers.RankingHandler(driver))
+	http.HandleFunc("/compare", handlers.CompareHandler(driver))
+	http.HandleFunc("/global", handlers.GlobalHandler(driver))
+	http.HandleFunc("/regions", handlers.RegionsHandler(driver))
+	http.HandleFunc("/regions/", handlers.RegionHandler(driver))
+	http.HandleFunc("/import-runs", handlers.ImportRunsHandler(driver))
+	http.HandleFunc("/countries", handlers.CountriesHandler(driver))
+	http.HandleFunc("/countries/", handlers.CountryHandler(driver))
 
 	log.Println("Server started at :8080")
 	log.Fatal(http.ListenAndServe(":8080", nil))
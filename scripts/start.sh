@@ -13,7 +13,7 @@ wait_for_neo4j() {
 if [ "$LOAD_DATA" = "true" ]; then
     echo "Running initial data load..."
     wait_for_neo4j
-    go run scripts/load_data.go
+    ./covidctl load all
 else
     echo "Skipping data load."
 fi